@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hayatosc/git-cx/internal/ai"
+	"github.com/hayatosc/git-cx/internal/git"
+)
+
+func newBranchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "branch [description...]",
+		Short: "Generate a branch name and switch to it",
+		Long: `Generate branch name candidates and create the chosen branch with git switch -c.
+
+Names are generated from the description given as arguments or, without
+one, from the staged diff (falling back to unstaged changes). Each
+candidate is formatted with cx.branch.pattern (default "{type}/{slug}"),
+which may also contain {ticket} for the value of --ticket, and is
+sanitised into a valid ref name (git check-ref-format).
+
+With --dry-run the chosen name is printed instead of switching.`,
+		Example: `  git cx branch "retry failed billing webhooks"
+  git cx branch --ticket ABC-123
+  git config cx.branch.pattern "{ticket}-{type}/{slug}"`,
+		SilenceUsage: true,
+		RunE:         runBranch,
+	}
+	cmd.Flags().String("ticket", "", "ticket ID substituted for {ticket} in cx.branch.pattern")
+	return cmd
+}
+
+func runBranch(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	gitRunner := git.NewRunner()
+
+	cfg, err := loadConfig(cmd, gitRunner)
+	if err != nil {
+		return err
+	}
+	provider, err := ai.NewProvider(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AI provider: %w", err)
+	}
+
+	req := ai.BranchRequest{
+		Description: strings.Join(args, " "),
+		Types:       cfg.Types,
+		Candidates:  cfg.Candidates,
+	}
+	if req.Description == "" {
+		if req.Diff, req.Stat, err = branchDiff(ctx, gitRunner); err != nil {
+			return err
+		}
+		if req.Diff == "" {
+			return fmt.Errorf("no changes to describe; pass a description: git cx branch \"what you are working on\"")
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "git-cx: generating branch names...")
+	names, err := ai.GenerateBranchNames(ctx, provider, req)
+	if err != nil {
+		return fmt.Errorf("failed to generate branch names: %w", err)
+	}
+	ticket, _ := cmd.Flags().GetString("ticket")
+	var options []string
+	seen := map[string]bool{}
+	for _, n := range names {
+		name := formatBranchName(cfg.Branch.Pattern, n, ticket)
+		if name == "" || seen[name] || gitRunner.CheckBranchName(ctx, name) != nil {
+			continue
+		}
+		seen[name] = true
+		options = append(options, name)
+	}
+	if len(options) == 0 {
+		return fmt.Errorf("provider returned no valid branch names")
+	}
+
+	name, err := pickCandidate("Select branch name", options)
+	if err != nil {
+		return err
+	}
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		fmt.Fprintln(cmd.OutOrStdout(), name)
+		return nil
+	}
+	if err := gitRunner.SwitchCreate(ctx, name); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Switched to a new branch '%s'\n", name)
+	return nil
+}
+
+// branchDiff returns the staged diff, or the unstaged one when nothing is staged.
+func branchDiff(ctx context.Context, runner git.Runner) (string, string, error) {
+	diff, err := runner.StagedDiff(ctx)
+	if err == nil {
+		stat, err := runner.StagedStat(ctx)
+		return diff, stat, err
+	}
+	if !errors.Is(err, git.ErrNoStagedChanges) {
+		return "", "", err
+	}
+	if diff, err = runner.UnstagedDiff(ctx); err != nil || diff == "" {
+		return "", "", err
+	}
+	stat, err := runner.UnstagedStat(ctx)
+	return diff, stat, err
+}
+
+// formatBranchName expands pattern for name and sanitises the result. The
+// ticket keeps its case; sanitising also drops the separators left dangling by an empty {ticket}.
+func formatBranchName(pattern string, name ai.BranchName, ticket string) string {
+	out := strings.NewReplacer(
+		"{type}", strings.ToLower(name.Type),
+		"{slug}", strings.ToLower(name.Slug),
+		"{ticket}", ticket,
+	).Replace(pattern)
+	return git.SanitizeBranchName(out)
+}
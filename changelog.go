@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hayatosc/git-cx/internal/ai"
+	"github.com/hayatosc/git-cx/internal/changelog"
+	"github.com/hayatosc/git-cx/internal/config"
+	"github.com/hayatosc/git-cx/internal/git"
+)
+
+func newChangelogCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "changelog [<from>..<to>]",
+		Short: "Generate a changelog from Conventional Commits history",
+		Long: `Generate a changelog from the Conventional Commits in a revision range.
+
+Without arguments the range is <latest tag>..HEAD (or the whole history when
+there are no tags). A single revision means <rev>..HEAD. Commits are grouped
+into Keep a Changelog sections by type and sorted by scope; breaking changes
+are listed first. Commits that are not Conventional Commits are skipped.
+
+With --ai the configured provider rewrites the entries as release-note prose.`,
+		Example: `  git cx changelog
+  git cx changelog v1.2.0..HEAD --version v1.3.0
+  git cx changelog --format json
+  git cx changelog --ai --output RELEASE_NOTES.md`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE:         runChangelog,
+	}
+	cmd.Flags().String("format", "markdown", "output format: markdown or json")
+	cmd.Flags().String("version", "", "version for the heading (default: Unreleased)")
+	cmd.Flags().String("date", "", "release date for the heading (default: today when --version is set)")
+	cmd.Flags().Bool("all", false, "include docs, style, test, build, ci and chore commits")
+	cmd.Flags().Bool("ai", false, "polish the entries into release-note prose with the AI provider")
+	cmd.Flags().StringP("output", "o", "", "write to this file instead of stdout")
+	return cmd
+}
+
+func runChangelog(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	gitRunner := git.NewRunner()
+	flags := cmd.Flags()
+
+	format, _ := flags.GetString("format")
+	useAI, _ := flags.GetBool("ai")
+	switch {
+	case format != "markdown" && format != "json":
+		return fmt.Errorf("unknown format %q (valid formats: markdown, json)", format)
+	case useAI && format == "json":
+		return fmt.Errorf("--ai only applies to markdown output")
+	}
+
+	revRange, err := changelogRange(ctx, gitRunner, args)
+	if err != nil {
+		return err
+	}
+	entries, err := gitRunner.Log(ctx, revRange)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(cmd, gitRunner)
+	if err != nil {
+		return err
+	}
+	opts := changelog.Options{Types: cfg.Types}
+	opts.Version, _ = flags.GetString("version")
+	opts.Date, _ = flags.GetString("date")
+	opts.All, _ = flags.GetBool("all")
+	if opts.Version != "" && opts.Date == "" {
+		opts.Date = time.Now().Format("2006-01-02")
+	}
+	cl := changelog.Build(entries, opts)
+
+	var out string
+	if format == "json" {
+		data, err := json.MarshalIndent(cl, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode changelog: %w", err)
+		}
+		out = string(data) + "\n"
+	} else {
+		out = cl.Markdown()
+		if useAI {
+			out, err = polishChangelog(ctx, cfg, out)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	path, _ := flags.GetString("output")
+	if path == "" {
+		fmt.Fprint(cmd.OutOrStdout(), out)
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(out), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// changelogRange resolves the command argument into a git log revision range.
+func changelogRange(ctx context.Context, runner git.Runner, args []string) (string, error) {
+	if len(args) > 0 {
+		if strings.Contains(args[0], "..") {
+			return args[0], nil
+		}
+		return args[0] + "..HEAD", nil
+	}
+	tag, err := runner.LatestTag(ctx)
+	if err != nil {
+		return "", err
+	}
+	if tag == "" {
+		return "HEAD", nil
+	}
+	return tag + "..HEAD", nil
+}
+
+func polishChangelog(ctx context.Context, cfg *config.Config, markdown string) (string, error) {
+	provider, err := ai.NewProvider(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize AI provider: %w", err)
+	}
+	notes, err := ai.PolishReleaseNotes(ctx, provider, markdown)
+	if err != nil {
+		return "", fmt.Errorf("failed to polish changelog: %w", err)
+	}
+	return notes + "\n", nil
+}
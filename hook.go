@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hayatosc/git-cx/internal/git"
+	"github.com/hayatosc/git-cx/internal/hook"
+)
+
+func newHookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hook",
+		Short: "Install or remove git-cx as a git hook",
+		Long: `Manage the git hooks installed by git-cx.
+
+The prepare-commit-msg hook lets plain 'git commit' (including commits from
+IDEs) fill in the message with git-cx. The commit-msg hook rejects messages
+that fail 'git cx lint'. An existing hook is kept and run first. Hooks are
+written to the directory git uses, honouring core.hooksPath.`,
+	}
+	cmd.AddCommand(newHookInstallCmd(), newHookUninstallCmd(), newHookStatusCmd())
+	return cmd
+}
+
+func newHookInstallCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "install [hook]",
+		Short: "Install a git-cx hook (default: prepare-commit-msg)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			dir, name, err := hookTarget(args)
+			if err != nil {
+				return err
+			}
+			st, err := hook.Install(dir, name)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Installed %s hook at %s\n", name, st.Path)
+			if st.Chained != "" {
+				fmt.Printf("Existing hook kept and chained: %s\n", st.Chained)
+			}
+			return nil
+		},
+	}
+}
+
+func newHookUninstallCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "uninstall [hook]",
+		Short: "Remove a git-cx hook and restore any chained hook",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			dir, name, err := hookTarget(args)
+			if err != nil {
+				return err
+			}
+			st, err := hook.Uninstall(dir, name)
+			if err != nil {
+				return err
+			}
+			if st.Foreign {
+				fmt.Printf("Removed %s hook; restored previous hook at %s\n", name, st.Path)
+				return nil
+			}
+			fmt.Printf("Removed %s hook from %s\n", name, st.Path)
+			return nil
+		},
+	}
+}
+
+func newHookStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show which git-cx hooks are installed",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			dir, err := git.NewRunner().HooksDir(context.Background())
+			if err != nil {
+				return err
+			}
+			fmt.Printf("hooks directory: %s\n", dir)
+			for _, name := range hook.Names() {
+				st, err := hook.Inspect(dir, name)
+				if err != nil {
+					return err
+				}
+				state := "not installed"
+				switch {
+				case st.Installed && st.Chained != "":
+					state = "installed (chained: " + st.Chained + ")"
+				case st.Installed:
+					state = "installed"
+				case st.Foreign:
+					state = "not installed (other hook present)"
+				}
+				fmt.Printf("%-20s %s\n", name+":", state)
+			}
+			return nil
+		},
+	}
+}
+
+func hookTarget(args []string) (string, string, error) {
+	name := hook.PrepareCommitMsg
+	if len(args) > 0 {
+		name = args[0]
+	}
+	if _, err := hook.Script(name); err != nil {
+		return "", "", err
+	}
+	dir, err := git.NewRunner().HooksDir(context.Background())
+	if err != nil {
+		return "", "", err
+	}
+	return dir, name, nil
+}
@@ -119,6 +119,28 @@ func (p *APIProvider) GenerateDetail(ctx context.Context, req GenerateRequest) (
 	return body, footer, nil
 }
 
+func (p *APIProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	if strings.TrimSpace(p.baseURL) == "" {
+		return "", fmt.Errorf("api base URL is not set (cx.apiBaseUrl) for api provider")
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(p.timeout)*time.Second)
+	defer cancel()
+
+	decoded, err := p.request(ctx, apiRequest{
+		Model: p.model,
+		Messages: []apiMessage{
+			{Role: "user", Content: prompt},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(decoded.Choices) == 0 {
+		return "", fmt.Errorf("api response missing choices")
+	}
+	return strings.TrimSpace(decoded.Choices[0].Message.Content), nil
+}
+
 func joinURL(baseURL, path string) (string, error) {
 	parsed, err := url.Parse(strings.TrimSpace(baseURL))
 	if err != nil {
@@ -0,0 +1,80 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hayatosc/git-cx/internal/commit"
+)
+
+// BranchRequest holds the input for branch name generation.
+type BranchRequest struct {
+	Description string // free-text description of the planned work
+	Diff        string
+	Stat        string
+	Types       commit.Types // allowed types; the built-in types when empty
+	Candidates  int
+}
+
+// BranchName is a generated branch name split into its parts.
+type BranchName struct {
+	Type string
+	Slug string
+}
+
+// buildBranchPrompt constructs the prompt for branch name generation.
+func buildBranchPrompt(req BranchRequest) string {
+	base := fmt.Sprintf(`You are a git branch name generator. Based on the work described below, generate %d branch name suggestions.
+
+Rules:
+- Format: <type>/<slug>
+- type must be one of: %s
+- slug is 2-5 lowercase words in kebab-case, ASCII letters and digits only, under 40 characters
+- Output ONLY the branch names, one per line, no numbering, no explanation
+
+`, req.Candidates, typeNames(req.Types))
+	base += typeGuide(req.Types)
+
+	if strings.TrimSpace(req.Description) != "" {
+		base += fmt.Sprintf("Work description: %s\n", strings.TrimSpace(req.Description))
+	}
+	if req.Stat != "" {
+		base += fmt.Sprintf("\nChanged files:\n%s\n", req.Stat)
+	}
+	if req.Diff == "" {
+		return base
+	}
+	return appendDiff(base, req.Diff)
+}
+
+// parseBranchOutput extracts type/slug pairs from AI output.
+func parseBranchOutput(output string, max int) []BranchName {
+	var names []BranchName
+	for _, line := range parseOutput(output, 0) {
+		line = strings.Trim(line, "`\"' ")
+		typ, slug, ok := strings.Cut(line, "/")
+		if !ok || typ == "" || slug == "" {
+			continue
+		}
+		names = append(names, BranchName{Type: strings.ToLower(typ), Slug: slug})
+		if max > 0 && len(names) >= max {
+			break
+		}
+	}
+	return names
+}
+
+// GenerateBranchNames asks the provider for branch name candidates.
+func GenerateBranchNames(ctx context.Context, p Provider, req BranchRequest) ([]BranchName, error) {
+	out, err := p.Complete(ctx, buildBranchPrompt(req))
+	if err != nil {
+		return nil, err
+	}
+	names := parseBranchOutput(out, req.Candidates)
+	if len(names) == 0 {
+		return nil, errors.New("provider returned no branch names")
+	}
+	return names, nil
+}
@@ -0,0 +1,28 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestBuildBranchPrompt_DescriptionOnly(t *testing.T) {
+	got := buildBranchPrompt(BranchRequest{Description: "retry billing webhooks", Candidates: 3})
+	if !containsAll(got, []string{"generate 3 branch name suggestions", "Work description: retry billing webhooks"}) {
+		t.Fatalf("prompt missing expected content:\n%s", got)
+	}
+	if strings.Contains(got, "Git diff:") {
+		t.Fatalf("prompt should not include an empty diff:\n%s", got)
+	}
+}
+
+func TestGenerateBranchNames(t *testing.T) {
+	provider := &MockProvider{Output: "feat/billing-retry-webhooks\n`fix/webhook-retries`\nnot a branch\nchore/x\n"}
+	got, err := GenerateBranchNames(context.Background(), provider, BranchRequest{Candidates: 2})
+	if err != nil {
+		t.Fatalf("GenerateBranchNames error: %v", err)
+	}
+	if len(got) != 2 || got[0] != (BranchName{Type: "feat", Slug: "billing-retry-webhooks"}) || got[1].Slug != "webhook-retries" {
+		t.Fatalf("unexpected names: %#v", got)
+	}
+}
@@ -0,0 +1,53 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// noBreakingChange is what the provider answers when the diff is compatible.
+const noBreakingChange = "NONE"
+
+// buildBreakingPrompt constructs the prompt for a BREAKING CHANGE footer.
+func buildBreakingPrompt(req GenerateRequest) string {
+	base := fmt.Sprintf(`You are a commit message generator. Based on the following git diff, describe the breaking change for the BREAKING CHANGE footer of a Conventional Commits message.
+
+Rules:
+- Look for removed or renamed exported symbols, changed function signatures, removed flags or config keys, and changed defaults or output formats
+- State what breaks and what users must do instead, in 1-3 short sentences
+- Plain text only, no "BREAKING CHANGE:" prefix
+- If nothing in the diff breaks compatibility, output only %s
+- Output ONLY the description, no explanation
+
+`, noBreakingChange)
+
+	if req.CommitType != "" {
+		base += fmt.Sprintf("Commit type is already selected: %s\n", req.CommitType)
+	}
+	if req.Scope != "" {
+		base += fmt.Sprintf("Scope is already selected: %s\n", req.Scope)
+	}
+	if req.Subject != "" {
+		base += fmt.Sprintf("Subject is already selected: %s\n", req.Subject)
+	}
+	if req.Stat != "" {
+		base += fmt.Sprintf("\nChanged files:\n%s\n", req.Stat)
+	}
+	return appendDiff(base, req.Diff)
+}
+
+// GenerateBreakingNote asks the provider to describe the breaking change in
+// the diff. It returns "" when the provider finds none.
+func GenerateBreakingNote(ctx context.Context, p Provider, req GenerateRequest) (string, error) {
+	out, err := p.Complete(ctx, buildBreakingPrompt(req))
+	if err != nil {
+		return "", err
+	}
+	out = stripCodeFence(out)
+	out = strings.TrimSpace(strings.TrimPrefix(out, "BREAKING CHANGE:"))
+	if strings.EqualFold(strings.Trim(out, ". "), noBreakingChange) {
+		return "", nil
+	}
+	return out, nil
+}
@@ -0,0 +1,25 @@
+package ai
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenerateBreakingNote(t *testing.T) {
+	provider := &MockProvider{Output: "BREAKING CHANGE: Config.Load now takes a context."}
+	got, err := GenerateBreakingNote(context.Background(), provider, GenerateRequest{Diff: "diff", Subject: "feat: x"})
+	if err != nil {
+		t.Fatalf("GenerateBreakingNote error: %v", err)
+	}
+	if got != "Config.Load now takes a context." {
+		t.Fatalf("unexpected note: %q", got)
+	}
+	if !containsAll(provider.LastPrompt, []string{"changed function signatures", "Subject is already selected: feat: x"}) {
+		t.Fatalf("prompt missing expected content:\n%s", provider.LastPrompt)
+	}
+
+	provider.Output = "NONE"
+	if got, err := GenerateBreakingNote(context.Background(), provider, GenerateRequest{}); err != nil || got != "" {
+		t.Fatalf("expected empty note, got %q %v", got, err)
+	}
+}
@@ -30,3 +30,7 @@ func (p *ClaudeProvider) Generate(ctx context.Context, req GenerateRequest) ([]s
 func (p *ClaudeProvider) GenerateDetail(ctx context.Context, req GenerateRequest) (string, string, error) {
 	return p.generateDetail(ctx, req)
 }
+
+func (p *ClaudeProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	return p.complete(ctx, prompt)
+}
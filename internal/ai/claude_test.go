@@ -41,3 +41,19 @@ func TestClaudeProviderGenerateDetailUsesCLI(t *testing.T) {
 		t.Fatalf("unexpected details: %q %q", body, footer)
 	}
 }
+
+func TestClaudeProviderCompleteUsesCLI(t *testing.T) {
+	runner := &execx.MockRunner{Strict: true}
+	key := "claude\x00-p\x00polish this\x00--model\x00claude-model"
+	runner.Results = map[string]execx.Result{key: {Stdout: "polished\n"}}
+
+	cfg := &config.Config{Model: "claude-model", Candidates: 1, Timeout: 1}
+	provider := NewClaudeProvider(cfg, runner)
+	got, err := provider.Complete(context.Background(), "polish this")
+	if err != nil {
+		t.Fatalf("Complete returned error: %v", err)
+	}
+	if got != "polished" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
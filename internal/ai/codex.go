@@ -30,3 +30,7 @@ func (p *CodexProvider) Generate(ctx context.Context, req GenerateRequest) ([]st
 func (p *CodexProvider) GenerateDetail(ctx context.Context, req GenerateRequest) (string, string, error) {
 	return p.generateDetail(ctx, req)
 }
+
+func (p *CodexProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	return p.complete(ctx, prompt)
+}
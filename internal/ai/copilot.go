@@ -30,3 +30,7 @@ func (p *CopilotProvider) Generate(ctx context.Context, req GenerateRequest) ([]
 func (p *CopilotProvider) GenerateDetail(ctx context.Context, req GenerateRequest) (string, string, error) {
 	return p.generateDetail(ctx, req)
 }
+
+func (p *CopilotProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	return p.complete(ctx, prompt)
+}
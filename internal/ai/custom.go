@@ -44,3 +44,12 @@ func (p *CustomProvider) GenerateDetail(ctx context.Context, req GenerateRequest
 	body, footer := parseDetailOutput(output)
 	return body, footer, nil
 }
+
+func (p *CustomProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	cmdStr := strings.ReplaceAll(p.command, "{prompt}", prompt)
+	output, err := runShellOutput(ctx, p.runner, cmdStr, p.timeout)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
@@ -30,3 +30,7 @@ func (p *GeminiProvider) Generate(ctx context.Context, req GenerateRequest) ([]s
 func (p *GeminiProvider) GenerateDetail(ctx context.Context, req GenerateRequest) (string, string, error) {
 	return p.generateDetail(ctx, req)
 }
+
+func (p *GeminiProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	return p.complete(ctx, prompt)
+}
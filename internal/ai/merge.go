@@ -0,0 +1,58 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MergeRequest holds the input for summarising a merge commit.
+type MergeRequest struct {
+	Header    string   // merge header prepared by git, e.g. "Merge branch 'x'"
+	Commits   []string // messages of the merged commits, oldest first
+	Conflicts []string // files that had conflicts
+	Stat      string
+}
+
+// buildMergePrompt constructs the prompt for a merge commit body.
+func buildMergePrompt(req MergeRequest) string {
+	var sb strings.Builder
+	sb.WriteString(`You are a commit message generator. Write the body of the merge commit below, summarising what the merged branch brings in.
+
+Rules:
+- 2-6 bullet points starting with "- ", grouped by theme rather than one per commit
+- Mention breaking changes first
+- Mention how conflicted files were reconciled if the commits make it clear
+- Plain text, lines under 72 characters
+- Output ONLY the body, no header, no explanation
+
+`)
+	fmt.Fprintf(&sb, "Merge header: %s\n", req.Header)
+	if len(req.Conflicts) > 0 {
+		fmt.Fprintf(&sb, "\nResolved conflicts in:\n%s\n", strings.Join(req.Conflicts, "\n"))
+	}
+	if req.Stat != "" {
+		fmt.Fprintf(&sb, "\nChanged files:\n%s\n", req.Stat)
+	}
+	if len(req.Commits) > 0 {
+		sb.WriteString("\nMerged commits:\n")
+		for _, c := range req.Commits {
+			fmt.Fprintf(&sb, "---\n%s\n", c)
+		}
+	}
+	return sb.String()
+}
+
+// GenerateMergeSummary asks the provider for the body of a merge commit.
+func GenerateMergeSummary(ctx context.Context, p Provider, req MergeRequest) (string, error) {
+	out, err := p.Complete(ctx, buildMergePrompt(req))
+	if err != nil {
+		return "", err
+	}
+	out = stripCodeFence(out)
+	if out == "" {
+		return "", errors.New("provider returned an empty merge summary")
+	}
+	return out, nil
+}
@@ -0,0 +1,24 @@
+package ai
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGenerateMergeSummary(t *testing.T) {
+	provider := &MockProvider{Output: "```\n- add billing retries\n```"}
+	got, err := GenerateMergeSummary(context.Background(), provider, MergeRequest{
+		Header:    "Merge branch 'feature/billing'",
+		Commits:   []string{"feat(billing): retry webhooks"},
+		Conflicts: []string{"billing.go"},
+	})
+	if err != nil {
+		t.Fatalf("GenerateMergeSummary error: %v", err)
+	}
+	if got != "- add billing retries" {
+		t.Fatalf("unexpected summary: %q", got)
+	}
+	if !containsAll(provider.LastPrompt, []string{"Merge header: Merge branch 'feature/billing'", "Resolved conflicts in:\nbilling.go", "feat(billing): retry webhooks"}) {
+		t.Fatalf("prompt missing expected content:\n%s", provider.LastPrompt)
+	}
+}
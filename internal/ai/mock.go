@@ -8,9 +8,11 @@ type MockProvider struct {
 	Candidates []string
 	Body       string
 	Footer     string
+	Output     string
 	Err        error
 	LastReq    *GenerateRequest
 	LastDetail *GenerateRequest
+	LastPrompt string
 }
 
 func (m *MockProvider) Generate(ctx context.Context, req GenerateRequest) ([]string, error) {
@@ -31,6 +33,15 @@ func (m *MockProvider) GenerateDetail(ctx context.Context, req GenerateRequest)
 	return m.Body, m.Footer, nil
 }
 
+func (m *MockProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	_ = ctx
+	m.LastPrompt = prompt
+	if m.Err != nil {
+		return "", m.Err
+	}
+	return m.Output, nil
+}
+
 func (m *MockProvider) Name() string {
 	if m.NameValue != "" {
 		return m.NameValue
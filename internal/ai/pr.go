@@ -0,0 +1,101 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PRRequest holds the input for pull request generation.
+type PRRequest struct {
+	Base       string
+	Diff       string
+	Stat       string
+	Commits    []string // full commit messages on the branch, oldest first
+	Template   string   // contents of the repository's pull request template, if any
+	Candidates int      // number of title candidates
+}
+
+// PRDraft is a generated pull request title and description.
+type PRDraft struct {
+	Titles []string
+	Body   string
+}
+
+const maxPRCommitsLen = 3000
+
+// buildPRPrompt constructs the prompt for pull request generation.
+func buildPRPrompt(req PRRequest) string {
+	base := fmt.Sprintf(`You are a pull request writer. Based on the commits and git diff below, write a pull request title and description.
+
+Rules:
+- Generate %d title candidates in Conventional Commits format: <type>(<scope>): <subject>
+- titles must be lowercase, imperative mood, no period at end, under 72 characters
+- The description is Markdown with a "## Summary" section (1-3 sentences), a "## Changes" bullet list and a "## Testing" section describing how the change was or should be verified
+- Do not invent changes that are not in the commits or diff
+- Output ONLY the result in this exact format:
+Titles:
+<one title per line>
+Body:
+<markdown description>
+
+`, req.Candidates)
+
+	if strings.TrimSpace(req.Template) != "" {
+		base += fmt.Sprintf("Fill in this pull request template for the description instead of the default sections, keeping its headings and order:\n```\n%s\n```\n\n", strings.TrimSpace(req.Template))
+	}
+	if req.Base != "" {
+		base += fmt.Sprintf("Base branch: %s\n", req.Base)
+	}
+	if len(req.Commits) > 0 {
+		commits := strings.Join(req.Commits, "\n---\n")
+		if len(commits) > maxPRCommitsLen {
+			commits = commits[:maxPRCommitsLen] + "\n(commits truncated)"
+		}
+		base += fmt.Sprintf("\nCommits on the branch:\n%s\n", commits)
+	}
+	if req.Stat != "" {
+		base += fmt.Sprintf("\nChanged files:\n%s\n", req.Stat)
+	}
+	return appendDiff(base, req.Diff)
+}
+
+// parsePROutput extracts title candidates and the description from AI output.
+func parsePROutput(output string, max int) PRDraft {
+	var titles []string
+	var body []string
+	section := ""
+	for _, line := range strings.Split(output, "\n") {
+		switch strings.TrimSpace(line) {
+		case "Titles:", "Title:":
+			section = "titles"
+			continue
+		case "Body:":
+			section = "body"
+			continue
+		}
+		switch section {
+		case "titles":
+			if t := strings.TrimSpace(line); t != "" && (max <= 0 || len(titles) < max) {
+				titles = append(titles, t)
+			}
+		case "body":
+			body = append(body, line)
+		}
+	}
+	return PRDraft{Titles: titles, Body: strings.TrimSpace(strings.Join(body, "\n"))}
+}
+
+// GeneratePR asks the provider for pull request title candidates and a description.
+func GeneratePR(ctx context.Context, p Provider, req PRRequest) (PRDraft, error) {
+	out, err := p.Complete(ctx, buildPRPrompt(req))
+	if err != nil {
+		return PRDraft{}, err
+	}
+	draft := parsePROutput(out, req.Candidates)
+	if len(draft.Titles) == 0 {
+		return PRDraft{}, errors.New("provider returned no pull request title")
+	}
+	return draft, nil
+}
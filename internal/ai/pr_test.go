@@ -0,0 +1,49 @@
+package ai
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBuildPRPrompt_IncludesCommitsAndTemplate(t *testing.T) {
+	got := buildPRPrompt(PRRequest{
+		Base:       "main",
+		Diff:       "diff --git a/a b/a",
+		Stat:       "a | 1 +",
+		Commits:    []string{"feat: add a", "fix: handle b"},
+		Template:   "## What\n\n## Why\n",
+		Candidates: 2,
+	})
+	if !containsAll(got, []string{
+		"Generate 2 title candidates",
+		"Fill in this pull request template",
+		"## What\n\n## Why",
+		"Base branch: main",
+		"feat: add a\n---\nfix: handle b",
+		"Changed files:\n",
+		"Git diff:",
+	}) {
+		t.Fatalf("prompt missing expected content:\n%s", got)
+	}
+}
+
+func TestGeneratePR_ParsesTitlesAndBody(t *testing.T) {
+	provider := &MockProvider{Output: "Titles:\nfeat: add a\nfeat(core): add a\nfeat: extra\nBody:\n## Summary\n\nAdds a.\n"}
+	draft, err := GeneratePR(context.Background(), provider, PRRequest{Candidates: 2})
+	if err != nil {
+		t.Fatalf("GeneratePR error: %v", err)
+	}
+	if len(draft.Titles) != 2 || draft.Titles[1] != "feat(core): add a" {
+		t.Fatalf("unexpected titles: %#v", draft.Titles)
+	}
+	if draft.Body != "## Summary\n\nAdds a." {
+		t.Fatalf("unexpected body: %q", draft.Body)
+	}
+}
+
+func TestGeneratePR_NoTitle(t *testing.T) {
+	provider := &MockProvider{Output: "I cannot help with that."}
+	if _, err := GeneratePR(context.Background(), provider, PRRequest{Candidates: 1}); err == nil {
+		t.Fatal("expected error when no title is returned")
+	}
+}
@@ -3,6 +3,8 @@ package ai
 import (
 	"fmt"
 	"strings"
+
+	"github.com/hayatosc/git-cx/internal/commit"
 )
 
 const maxDiffLen = 4000
@@ -20,19 +22,63 @@ func appendDiff(base, diff string) string {
 	return base
 }
 
+// typeNames lists the allowed commit types for a prompt rule.
+func typeNames(types commit.Types) string {
+	if len(types) == 0 {
+		types = commit.DefaultTypes()
+	}
+	return types.String()
+}
+
+// typeGuide describes the configured types that are not built in, so the
+// model knows when to use them. It is empty for the built-in set.
+func typeGuide(types commit.Types) string {
+	var sb strings.Builder
+	for _, t := range types {
+		if def, ok := commit.DefaultTypes().Lookup(t.Name); ok && def.Description == t.Description {
+			continue
+		}
+		if t.Description != "" {
+			fmt.Fprintf(&sb, "- %s: %s\n", t.Name, t.Description)
+		}
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	return "Project-specific types:\n" + sb.String() + "\n"
+}
+
+// gitmojiGuide asks for the most specific gitmoji and lists the catalogue.
+func gitmojiGuide() string {
+	var sb strings.Builder
+	sb.WriteString("Gitmoji:\n")
+	sb.WriteString("- Start each subject with the single most specific gitmoji shortcode: <type>(<scope>): :<code>: <subject>\n")
+	sb.WriteString("- Prefer a precise gitmoji over a generic one (e.g. :lock: for a security fix rather than :bug:)\n")
+	sb.WriteString("- Available gitmojis:\n")
+	for _, g := range commit.Gitmojis {
+		fmt.Fprintf(&sb, "  %s %s\n", g.Shortcode(), g.Description)
+	}
+	return sb.String() + "\n"
+}
+
 // buildPrompt constructs the prompt string sent to the AI provider.
 func buildPrompt(req GenerateRequest) string {
 	base := fmt.Sprintf(`You are a commit message generator. Based on the following git diff, generate %d commit message suggestions in Conventional Commits format.
 
 Rules:
 - Format: <type>(<scope>): <subject>
-- type must be one of: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert
+- type must be one of: %s
 - scope is optional
 - subject must be lowercase, imperative mood, no period at end
 - subject must be concise (under 72 characters)
-- Output ONLY the commit messages, one per line, no numbering, no explanation
+- Output ONLY the commit messages, one per line, no numbering
+- End each line with " // " and one short sentence on why you chose its type and scope, e.g. "fix: handle nil body // fix because a nil check was added in the handler"
 
-`, req.Candidates)
+`, req.Candidates, typeNames(req.Types))
+	base += typeGuide(req.Types)
+	if req.Gitmoji {
+		base += gitmojiGuide()
+	}
 
 	if req.CommitType != "" {
 		base += fmt.Sprintf("Commit type is already selected: %s\n", req.CommitType)
@@ -43,6 +89,7 @@ Rules:
 	if req.Subject != "" {
 		base += fmt.Sprintf("Subject is already selected: %s\n", req.Subject)
 	}
+	base += regenerateGuide(req)
 
 	if req.Stat != "" {
 		base += fmt.Sprintf("\nChanged files:\n%s\n", req.Stat)
@@ -51,6 +98,22 @@ Rules:
 	return appendDiff(base, req.Diff)
 }
 
+// regenerateGuide asks for candidates unlike the rejected ones and passes on
+// the user's instruction. It is empty for a first generation.
+func regenerateGuide(req GenerateRequest) string {
+	var sb strings.Builder
+	if len(req.Rejected) > 0 {
+		sb.WriteString("\nThe user rejected these suggestions; do not repeat them:\n")
+		for _, r := range req.Rejected {
+			fmt.Fprintf(&sb, "- %s\n", r)
+		}
+	}
+	if req.Hint != "" {
+		fmt.Fprintf(&sb, "\nInstruction from the user (takes precedence over the rules above except the format): %s\n", req.Hint)
+	}
+	return sb.String()
+}
+
 // buildDetailPrompt constructs the prompt for body/footer generation.
 func buildDetailPrompt(req GenerateRequest) string {
 	base := `You are a commit message generator. Based on the following git diff, generate a commit body and footer for the subject below.
@@ -83,6 +146,25 @@ Footer:
 	return appendDiff(base, req.Diff)
 }
 
+// rationaleSeparator divides a generated candidate line into header and
+// rationale, as asked for by buildPrompt.
+const rationaleSeparator = " // "
+
+// ParseCandidates splits the lines returned by Provider.Generate into
+// headers and rationales. Lines without a rationale are kept as they are.
+func ParseCandidates(lines []string) []Candidate {
+	candidates := make([]Candidate, 0, len(lines))
+	for _, line := range lines {
+		header, rationale, _ := strings.Cut(line, rationaleSeparator)
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		candidates = append(candidates, Candidate{Header: header, Rationale: strings.TrimSpace(rationale)})
+	}
+	return candidates
+}
+
 // parseDetailOutput extracts body and footer from AI output.
 func parseDetailOutput(output string) (string, string) {
 	const bodyLabel = "Body:"
@@ -3,6 +3,8 @@ package ai
 import (
 	"strings"
 	"testing"
+
+	"github.com/hayatosc/git-cx/internal/commit"
 )
 
 func TestBuildPrompt_IncludesStatAndSelections(t *testing.T) {
@@ -28,6 +30,52 @@ func TestBuildPrompt_IncludesStatAndSelections(t *testing.T) {
 	}
 }
 
+func TestBuildPrompt_CustomTypes(t *testing.T) {
+	types := append(commit.DefaultTypes(), commit.Type{Name: "sec", Description: "Security fixes"})
+	got := buildPrompt(GenerateRequest{Diff: "diff", Types: types, Candidates: 1})
+	if !containsAll(got, []string{
+		"type must be one of: feat, fix, docs, style, refactor, perf, test, build, ci, chore, revert, sec\n",
+		"Project-specific types:\n- sec: Security fixes\n",
+	}) {
+		t.Fatalf("prompt missing configured types:\n%s", got)
+	}
+	if strings.Contains(got, "- feat: A new feature") {
+		t.Fatalf("built-in types should not be described:\n%s", got)
+	}
+}
+
+func TestBuildPrompt_Gitmoji(t *testing.T) {
+	got := buildPrompt(GenerateRequest{Diff: "diff", Gitmoji: true, Candidates: 1})
+	if !containsAll(got, []string{
+		"<type>(<scope>): :<code>: <subject>",
+		"  :lock: Fix security or privacy issues\n",
+		"  :sparkles: Introduce new features\n",
+	}) {
+		t.Fatalf("prompt missing gitmoji guide:\n%s", got)
+	}
+	if strings.Contains(buildPrompt(GenerateRequest{Diff: "diff", Candidates: 1}), "Gitmoji:") {
+		t.Fatal("gitmoji guide should only be added in gitmoji mode")
+	}
+}
+
+func TestBuildPrompt_RegenerateWithHint(t *testing.T) {
+	got := buildPrompt(GenerateRequest{
+		Diff:       "diff",
+		Candidates: 2,
+		Hint:       "mention the migration",
+		Rejected:   []string{"feat: add x", "feat: add y"},
+	})
+	if !containsAll(got, []string{
+		"do not repeat them:\n- feat: add x\n- feat: add y\n",
+		"Instruction from the user (takes precedence over the rules above except the format): mention the migration\n",
+	}) {
+		t.Fatalf("prompt missing regenerate guide:\n%s", got)
+	}
+	if strings.Contains(buildPrompt(GenerateRequest{Diff: "diff", Candidates: 1}), "Instruction from the user") {
+		t.Fatal("regenerate guide should only be added when regenerating")
+	}
+}
+
 func TestBuildDetailPrompt_IncludesSubject(t *testing.T) {
 	req := GenerateRequest{
 		Diff:       "diff --git a/a b/a",
@@ -61,6 +109,29 @@ func TestParseDetailOutput(t *testing.T) {
 	}
 }
 
+func TestParseCandidates(t *testing.T) {
+	got := ParseCandidates([]string{
+		"fix(api): handle nil body // fix because a nil check was added in the handler",
+		"feat: add retry",
+		" // no header",
+	})
+	want := []Candidate{
+		{Header: "fix(api): handle nil body", Rationale: "fix because a nil check was added in the handler"},
+		{Header: "feat: add retry"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected candidates: %#v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("candidate %d: got %#v, want %#v", i, got[i], want[i])
+		}
+	}
+	if !strings.Contains(buildPrompt(GenerateRequest{Candidates: 1}), `" // "`) {
+		t.Errorf("prompt does not ask for a rationale")
+	}
+}
+
 func containsAll(s string, parts []string) bool {
 	for _, p := range parts {
 		if !strings.Contains(s, p) {
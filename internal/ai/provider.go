@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hayatosc/git-cx/internal/commit"
 	"github.com/hayatosc/git-cx/internal/config"
 	"github.com/hayatosc/git-cx/internal/execx"
 )
@@ -15,6 +16,8 @@ import (
 type Provider interface {
 	Generate(ctx context.Context, req GenerateRequest) ([]string, error)
 	GenerateDetail(ctx context.Context, req GenerateRequest) (string, string, error)
+	// Complete sends a free-form prompt and returns the raw model output.
+	Complete(ctx context.Context, prompt string) (string, error)
 	Name() string
 }
 
@@ -25,7 +28,18 @@ type GenerateRequest struct {
 	CommitType string
 	Scope      string
 	Subject    string
+	Types      commit.Types // allowed types; the built-in types when empty
+	Gitmoji    bool         // ask for a gitmoji shortcode before the subject
 	Candidates int
+	Hint       string   // user instruction to steer regenerated candidates
+	Rejected   []string // earlier candidates the user passed over
+}
+
+// Candidate is a generated commit header with the model's one-sentence
+// reason for it, e.g. "fix because a nil check was added in the handler".
+type Candidate struct {
+	Header    string
+	Rationale string // empty when the model gave none
 }
 
 // NewProvider returns the appropriate Provider based on config.
@@ -87,6 +101,18 @@ func (p *cliProvider) generateDetail(ctx context.Context, req GenerateRequest) (
 	return body, footer, nil
 }
 
+func (p *cliProvider) complete(ctx context.Context, prompt string) (string, error) {
+	args := []string{p.cfg.promptFlag, prompt}
+	if p.model != "" {
+		args = append(args, p.cfg.modelFlag, p.model)
+	}
+	output, err := runCLIOutput(ctx, p.runner, p.cfg.name, args, p.timeout)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
 // runCLI executes name with args, returning parsed candidate lines.
 func runCLI(ctx context.Context, runner execx.Runner, name string, args []string, timeout, max int) ([]string, error) {
 	output, err := runCLIOutput(ctx, runner, name, args, timeout)
@@ -0,0 +1,52 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// buildReleaseNotesPrompt constructs the prompt that turns a generated
+// changelog into release-note prose.
+func buildReleaseNotesPrompt(changelog string) string {
+	return fmt.Sprintf(`You are a release notes writer. Rewrite the following Markdown changelog into polished release notes.
+
+Rules:
+- Keep the existing Markdown headings, their order and every commit reference in parentheses
+- Turn terse commit subjects into short, user-facing sentences
+- Merge entries that describe the same change
+- Keep breaking changes prominent and explain what users must do
+- Do not invent changes that are not listed
+- Output ONLY the Markdown, no preamble or explanation
+
+Changelog:
+%s
+`, changelog)
+}
+
+// PolishReleaseNotes asks the provider to rewrite a changelog as release notes.
+func PolishReleaseNotes(ctx context.Context, p Provider, changelog string) (string, error) {
+	out, err := p.Complete(ctx, buildReleaseNotesPrompt(changelog))
+	if err != nil {
+		return "", err
+	}
+	out = stripCodeFence(out)
+	if out == "" {
+		return "", errors.New("provider returned empty release notes")
+	}
+	return out, nil
+}
+
+// stripCodeFence removes a Markdown code fence wrapped around the whole output.
+func stripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") || !strings.HasSuffix(s, "```") {
+		return s
+	}
+	_, rest, ok := strings.Cut(s, "\n")
+	if !ok {
+		return s
+	}
+	return strings.TrimSpace(strings.TrimSuffix(rest, "```"))
+}
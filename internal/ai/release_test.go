@@ -0,0 +1,21 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPolishReleaseNotes(t *testing.T) {
+	provider := &MockProvider{Output: "```markdown\n### Added\n\n- Webhooks are retried (abc1234)\n```"}
+	got, err := PolishReleaseNotes(context.Background(), provider, "### Added\n\n- retry webhooks (abc1234)")
+	if err != nil {
+		t.Fatalf("PolishReleaseNotes error: %v", err)
+	}
+	if got != "### Added\n\n- Webhooks are retried (abc1234)" {
+		t.Fatalf("unexpected notes: %q", got)
+	}
+	if !strings.Contains(provider.LastPrompt, "- retry webhooks (abc1234)") {
+		t.Fatalf("prompt missing changelog:\n%s", provider.LastPrompt)
+	}
+}
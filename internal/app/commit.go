@@ -3,6 +3,9 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"slices"
 	"strings"
 
 	"github.com/hayatosc/git-cx/internal/ai"
@@ -13,14 +16,24 @@ import (
 
 // CommitService coordinates commit flow.
 type CommitService struct {
-	cfg      *config.Config
-	provider ai.Provider
-	git      git.Runner
+	cfg         *config.Config
+	provider    ai.Provider
+	git         git.Runner
+	messageFile string
+	commitOpts  git.CommitOptions
+	draftPath   string // set by draftLocation
+	draftTree   string
+	draftHead   string
 }
 
 // NewCommitService builds a service with dependencies.
 func NewCommitService(cfg *config.Config, provider ai.Provider, gitRunner git.Runner) *CommitService {
-	return &CommitService{cfg: cfg, provider: provider, git: gitRunner}
+	return &CommitService{
+		cfg:        cfg,
+		provider:   provider,
+		git:        gitRunner,
+		commitOpts: git.CommitOptions{Signoff: cfg.Commit.Signoff, GPGSign: cfg.Commit.GPGSign},
+	}
 }
 
 // StagedChanges returns staged diff and stat.
@@ -36,16 +49,32 @@ func (s *CommitService) StagedChanges(ctx context.Context) (string, string, erro
 	return diff, stat, nil
 }
 
-// GenerateCandidates generates commit message candidates.
-func (s *CommitService) GenerateCandidates(ctx context.Context, diff, stat, commitType, scope string) ([]string, error) {
+// GenerateCandidates generates commit message candidates with the model's
+// rationale for each.
+func (s *CommitService) GenerateCandidates(ctx context.Context, diff, stat, commitType, scope string) ([]ai.Candidate, error) {
+	return s.RegenerateCandidates(ctx, diff, stat, commitType, scope, "", nil)
+}
+
+// RegenerateCandidates is like GenerateCandidates but asks for candidates
+// unlike rejected and steered by hint, a short instruction such as
+// "shorter" or "mention the migration".
+func (s *CommitService) RegenerateCandidates(ctx context.Context, diff, stat, commitType, scope, hint string, rejected []string) ([]ai.Candidate, error) {
 	req := ai.GenerateRequest{
 		Diff:       diff,
 		Stat:       stat,
 		CommitType: commitType,
 		Scope:      scope,
+		Types:      s.cfg.Types,
+		Gitmoji:    s.cfg.Commit.Gitmoji,
 		Candidates: s.cfg.Candidates,
+		Hint:       hint,
+		Rejected:   rejected,
 	}
-	return s.provider.Generate(ctx, req)
+	lines, err := s.provider.Generate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return ai.ParseCandidates(lines), nil
 }
 
 // GenerateDetails generates commit body and footer.
@@ -56,20 +85,304 @@ func (s *CommitService) GenerateDetails(ctx context.Context, diff, stat, commitT
 		CommitType: commitType,
 		Scope:      scope,
 		Subject:    subject,
+		Types:      s.cfg.Types,
 		Candidates: 1,
 	}
 	return s.provider.GenerateDetail(ctx, req)
 }
 
+// GenerateBreakingNote asks the AI for a BREAKING CHANGE description of the
+// diff; "" means it found no breaking change.
+func (s *CommitService) GenerateBreakingNote(ctx context.Context, diff, stat, commitType, scope, subject string) (string, error) {
+	req := ai.GenerateRequest{
+		Diff:       diff,
+		Stat:       stat,
+		CommitType: commitType,
+		Scope:      scope,
+		Subject:    subject,
+		Types:      s.cfg.Types,
+		Candidates: 1,
+	}
+	return ai.GenerateBreakingNote(ctx, s.provider, req)
+}
+
+// InProgress reports the merge, revert or cherry-pick the next commit concludes.
+func (s *CommitService) InProgress(ctx context.Context) git.Operation {
+	return s.git.InProgressOperation(ctx)
+}
+
+// OperationMessage returns the header and body of the commit that concludes
+// op. Merges are summarised by the AI provider; on failure the header and
+// conflict list are still returned alongside the error. Reverts and
+// cherry-picks are derived from the original commit.
+func (s *CommitService) OperationMessage(ctx context.Context, op git.Operation, stat string) (string, string, error) {
+	switch op.Kind {
+	case git.OperationRevert:
+		orig, err := s.git.CommitMessage(ctx, op.Head)
+		if err != nil {
+			return "", "", err
+		}
+		header, _, _ := strings.Cut(orig.Message, "\n")
+		msg := commit.Format(commit.Revert(header, orig.Hash), s.formatOptions())
+		subject, body, _ := strings.Cut(msg, "\n\n")
+		return subject, body, nil
+	case git.OperationCherryPick:
+		orig, err := s.git.CommitMessage(ctx, op.Head)
+		if err != nil {
+			return "", "", err
+		}
+		subject, body, _ := strings.Cut(orig.Message, "\n\n")
+		return subject, body, nil
+	case git.OperationMerge:
+		return s.mergeMessage(ctx, op, stat)
+	}
+	return "", "", fmt.Errorf("no merge, revert or cherry-pick in progress")
+}
+
+func (s *CommitService) mergeMessage(ctx context.Context, op git.Operation, stat string) (string, string, error) {
+	prepared, err := s.git.MergeMessage(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	header, conflicts := git.ParseMergeMessage(prepared)
+	if header == "" {
+		header = fmt.Sprintf("Merge commit '%s'", op.Head)
+	}
+	var conflictList string
+	if len(conflicts) > 0 {
+		conflictList = "Resolved conflicts:\n- " + strings.Join(conflicts, "\n- ")
+	}
+
+	entries, err := s.git.Log(ctx, "HEAD.."+op.Head)
+	if err != nil {
+		return header, conflictList, err
+	}
+	commits := make([]string, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		commits = append(commits, entries[i].Message)
+	}
+	summary, err := ai.GenerateMergeSummary(ctx, s.provider, ai.MergeRequest{
+		Header:    header,
+		Commits:   commits,
+		Conflicts: conflicts,
+		Stat:      stat,
+	})
+	if err != nil {
+		return header, conflictList, err
+	}
+	if conflictList != "" {
+		summary += "\n\n" + conflictList
+	}
+	return header, summary, nil
+}
+
+// Types returns the configured commit types in display order, falling back
+// to the built-in types.
+func (s *CommitService) Types() commit.Types {
+	if len(s.cfg.Types) == 0 {
+		return commit.DefaultTypes()
+	}
+	return s.cfg.Types
+}
+
+// Gitmoji reports whether the emoji is chosen from the gitmoji catalogue.
+func (s *CommitService) Gitmoji() bool {
+	return s.cfg.Commit.Gitmoji
+}
+
+// TypeEmoji returns the emoji configured for commitType, if any.
+func (s *CommitService) TypeEmoji(commitType string) string {
+	t, _ := s.Types().Lookup(commitType)
+	return t.Emoji
+}
+
+// CheckHeader lints the header of c with the cx.lint.* rules. A subject
+// wider than cx.commit.maxSubjectLength, which BuildMessage would truncate,
+// is reported as a header-max-length error.
+func (s *CommitService) CheckHeader(c *commit.ConventionalCommit) []commit.LintIssue {
+	var issues []commit.LintIssue
+	if limit := s.cfg.Commit.MaxSubjectLength; limit > 0 {
+		if n := commit.Width(c.Subject); n > limit {
+			issues = append(issues, commit.LintIssue{
+				Rule:    commit.RuleHeaderMaxLength,
+				Level:   commit.LevelError,
+				Line:    1,
+				Message: fmt.Sprintf("subject is %d columns, max is %d", n, limit),
+			})
+		}
+	}
+	rules, _ := s.cfg.LintRules() // unknown rule names are reported by git cx lint
+	header := commit.Format(&commit.ConventionalCommit{Type: c.Type, Scope: c.Scope, Breaking: c.Breaking, Subject: c.Subject}, commit.FormatOptions{})
+	return append(issues, commit.Lint(header, rules)...)
+}
+
 // BuildMessage formats commit message.
 func (s *CommitService) BuildMessage(c *commit.ConventionalCommit) string {
-	return commit.BuildMessage(c, s.cfg.Commit.UseEmoji, s.cfg.Commit.MaxSubjectLength)
+	opts := s.formatOptions()
+	opts.MaxSubjectLength = s.cfg.Commit.MaxSubjectLength
+	return commit.BuildMessage(c, opts)
+}
+
+func (s *CommitService) formatOptions() commit.FormatOptions {
+	return commit.FormatOptions{
+		Types:            s.cfg.Types,
+		UseEmoji:         s.cfg.Commit.UseEmoji || s.cfg.Commit.Gitmoji,
+		EmojiPlacement:   s.cfg.Commit.EmojiPlacement,
+		EmojiFormat:      s.cfg.Commit.EmojiFormat,
+		BodyWrap:         s.cfg.Commit.BodyWrap,
+		NormalizeBullets: s.cfg.Commit.NormalizeBullets,
+	}
+}
+
+// DefaultTrailers returns the trailers configured in cx.commit.trailers.
+// Entries without a value ("Signed-off-by") get the committer identity.
+func (s *CommitService) DefaultTrailers(ctx context.Context) []commit.Footer {
+	var trailers []commit.Footer
+	var ident string
+	for _, entry := range s.cfg.Commit.Trailers {
+		token, value, _ := strings.Cut(entry, ":")
+		token, value = strings.TrimSpace(token), strings.TrimSpace(value)
+		if token == "" {
+			continue
+		}
+		if value == "" {
+			if ident == "" {
+				ident, _ = s.git.Identity(ctx)
+			}
+			value = ident
+		}
+		trailers = append(trailers, commit.Footer{Token: token, Separator: ": ", Value: value})
+	}
+	return trailers
+}
+
+// CoAuthor is a suggested Co-authored-by identity.
+type CoAuthor struct {
+	Ident      string // "Name <email>"
+	Configured bool   // from cx.coauthors rather than git log
+}
+
+// recentCommitLimit bounds the history scanned for co-author suggestions.
+const recentCommitLimit = 200
+
+// CoAuthors suggests people for Co-authored-by trailers: cx.coauthors first,
+// then recent authors of the staged files (of the repository when nothing
+// is staged). The committer is left out.
+func (s *CommitService) CoAuthors(ctx context.Context) ([]CoAuthor, error) {
+	self, _ := s.git.Identity(ctx)
+	seen := map[string]bool{strings.ToLower(git.IdentEmail(self)): true}
+	var result []CoAuthor
+	add := func(ident string, configured bool) {
+		key := strings.ToLower(git.IdentEmail(ident))
+		if ident == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		result = append(result, CoAuthor{Ident: ident, Configured: configured})
+	}
+	for _, ident := range s.cfg.CoAuthors {
+		add(strings.TrimSpace(ident), true)
+	}
+
+	files, err := s.git.StagedFiles(ctx)
+	if err != nil {
+		return result, err
+	}
+	recent, err := s.git.RecentAuthors(ctx, files, recentCommitLimit)
+	if err != nil {
+		return result, err
+	}
+	for _, ident := range recent {
+		add(ident, false)
+	}
+	return result, nil
+}
+
+// FinalMessage builds the message for c and appends trailers with
+// git interpret-trailers, so the user's trailer.* configuration applies.
+// Trailers with an empty value are skipped. If git fails, the trailers are
+// appended as plain footers and the error is returned alongside.
+func (s *CommitService) FinalMessage(ctx context.Context, c *commit.ConventionalCommit, trailers []commit.Footer) (string, error) {
+	var args []string
+	var kept []commit.Footer
+	for _, t := range trailers {
+		if strings.TrimSpace(t.Value) == "" {
+			continue
+		}
+		kept = append(kept, t)
+		value := t.Value
+		if t.Separator == " #" {
+			value = "#" + value // git only splits on ":" by default
+		}
+		args = append(args, t.Token+": "+value)
+	}
+	msg := s.BuildMessage(c)
+	out, err := s.git.InterpretTrailers(ctx, msg, args)
+	if err != nil {
+		fallback := *c
+		fallback.Footers = append(slices.Clone(c.Footers), kept...)
+		return s.BuildMessage(&fallback), err
+	}
+	return out, nil
+}
+
+// SetMessageFile makes Commit write the message to path instead of running
+// git commit. It is used when git-cx runs as a prepare-commit-msg hook.
+func (s *CommitService) SetMessageFile(path string) {
+	s.messageFile = path
 }
 
-// Commit executes git commit.
+// MessageFile returns the path set by SetMessageFile, if any.
+func (s *CommitService) MessageFile() string {
+	return s.messageFile
+}
+
+// SetCommitOptions sets the flags Commit passes to git commit.
+func (s *CommitService) SetCommitOptions(opts git.CommitOptions) {
+	s.commitOpts = opts
+}
+
+// UI returns the cx.ui.* settings the TUI themes and binds keys from.
+func (s *CommitService) UI() config.UIConfig {
+	return s.cfg.UI
+}
+
+// CommitOptions returns the flags Commit passes to git commit. They start
+// from cx.commit.signoff and cx.commit.gpgSign.
+func (s *CommitService) CommitOptions() git.CommitOptions {
+	return s.commitOpts
+}
+
+// Commit executes git commit, or writes the message file in hook mode.
 func (s *CommitService) Commit(ctx context.Context, message string) (string, error) {
 	if strings.TrimSpace(message) == "" {
 		return "", errors.New("commit message is empty")
 	}
-	return s.git.Commit(ctx, message)
+	if s.messageFile != "" {
+		return "", writeMessageFile(s.messageFile, message)
+	}
+	return s.git.Commit(ctx, message, s.commitOpts)
+}
+
+// writeMessageFile replaces the message in path, keeping git's comment lines
+// (status, template hints) below it for the editor that git opens next.
+func writeMessageFile(path, message string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read message file: %w", err)
+	}
+	var comments []string
+	for _, line := range strings.Split(string(existing), "\n") {
+		if strings.HasPrefix(line, "#") {
+			comments = append(comments, line)
+		}
+	}
+	content := strings.TrimRight(message, "\n") + "\n"
+	if len(comments) > 0 {
+		content += "\n" + strings.Join(comments, "\n") + "\n"
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write message file: %w", err)
+	}
+	return nil
 }
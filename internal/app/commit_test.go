@@ -3,6 +3,9 @@ package app
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hayatosc/git-cx/internal/ai"
@@ -13,7 +16,7 @@ import (
 )
 
 func TestCommitService_GenerateCandidates(t *testing.T) {
-	provider := &ai.MockProvider{Candidates: []string{"feat: ok"}}
+	provider := &ai.MockProvider{Candidates: []string{"feat: ok // feat because a new option is added"}}
 	service := NewCommitService(
 		&config.Config{Candidates: 1, Commit: config.CommitConfig{}},
 		provider,
@@ -24,7 +27,7 @@ func TestCommitService_GenerateCandidates(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(got) != 1 || got[0] != "feat: ok" {
+	if len(got) != 1 || got[0] != (ai.Candidate{Header: "feat: ok", Rationale: "feat because a new option is added"}) {
 		t.Fatalf("unexpected candidates: %#v", got)
 	}
 	if provider.LastReq == nil || provider.LastReq.CommitType != "feat" || provider.LastReq.Scope != "core" {
@@ -32,6 +35,18 @@ func TestCommitService_GenerateCandidates(t *testing.T) {
 	}
 }
 
+func TestCommitService_RegenerateCandidates(t *testing.T) {
+	provider := &ai.MockProvider{Candidates: []string{"feat: ok"}}
+	service := NewCommitService(&config.Config{Candidates: 1}, provider, git.NewRunnerWithExecutor(&execx.MockRunner{}))
+
+	if _, err := service.RegenerateCandidates(context.Background(), "diff", "stat", "", "", "shorter", []string{"feat: too long"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req := provider.LastReq; req == nil || req.Hint != "shorter" || len(req.Rejected) != 1 || req.Rejected[0] != "feat: too long" {
+		t.Fatalf("hint and rejected candidates not passed on: %#v", req)
+	}
+}
+
 func TestCommitService_GenerateDetails(t *testing.T) {
 	provider := &ai.MockProvider{Body: "body", Footer: "footer"}
 	service := NewCommitService(
@@ -78,10 +93,25 @@ func TestCommitService_BuildMessage(t *testing.T) {
 	}
 }
 
+func TestCommitService_CheckHeader(t *testing.T) {
+	service := NewCommitService(
+		&config.Config{Candidates: 1, Commit: config.CommitConfig{MaxSubjectLength: 10}},
+		&ai.MockProvider{},
+		git.NewRunnerWithExecutor(&execx.MockRunner{}),
+	)
+	if issues := service.CheckHeader(&commit.ConventionalCommit{Type: "feat", Subject: "add it"}); len(issues) != 0 {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+	issues := service.CheckHeader(&commit.ConventionalCommit{Type: "feature", Subject: "add a long subject"})
+	if len(issues) != 2 || issues[0].Rule != commit.RuleHeaderMaxLength || issues[1].Rule != commit.RuleTypeEnum {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+}
+
 func TestCommitService_CommitPropagatesError(t *testing.T) {
 	mock := &execx.MockRunner{
 		Errors: map[string]error{
-			"git\x00commit\x00-m\x00msg": errors.New("fail"),
+			"git\x00commit\x00-F\x00-": errors.New("fail"),
 		},
 	}
 	service := NewCommitService(
@@ -94,3 +124,162 @@ func TestCommitService_CommitPropagatesError(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestCommitService_CommitUsesConfiguredOptions(t *testing.T) {
+	mock := &execx.MockRunner{}
+	service := NewCommitService(
+		&config.Config{Candidates: 1, Commit: config.CommitConfig{Signoff: true, GPGSign: true}},
+		&ai.MockProvider{},
+		git.NewRunnerWithExecutor(mock),
+	)
+	opts := service.CommitOptions()
+	opts.NoVerify = true
+	service.SetCommitOptions(opts)
+
+	if _, err := service.Commit(context.Background(), "feat: sign"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mock.Calls) != 1 {
+		t.Fatalf("expected one git call, got %#v", mock.Calls)
+	}
+	got := strings.Join(mock.Calls[0].Args, " ")
+	if got != "commit -F - --signoff -S --no-verify" || mock.Calls[0].Input != "feat: sign" {
+		t.Fatalf("unexpected call: %q input %q", got, mock.Calls[0].Input)
+	}
+}
+
+func TestCommitService_CommitWritesMessageFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	template := "\n# Please enter the commit message for your changes.\n# On branch main\n"
+	if err := os.WriteFile(path, []byte(template), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mock := &execx.MockRunner{Strict: true}
+	service := NewCommitService(
+		&config.Config{Candidates: 1, Commit: config.CommitConfig{}},
+		&ai.MockProvider{},
+		git.NewRunnerWithExecutor(mock),
+	)
+	service.SetMessageFile(path)
+
+	if _, err := service.Commit(context.Background(), "feat: hook\n\nbody"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mock.Calls) != 0 {
+		t.Fatalf("git should not be called in hook mode: %#v", mock.Calls)
+	}
+	data, _ := os.ReadFile(path)
+	want := "feat: hook\n\nbody\n\n# Please enter the commit message for your changes.\n# On branch main\n"
+	if string(data) != want {
+		t.Fatalf("unexpected message file:\n%q", data)
+	}
+}
+
+func TestCommitService_OperationMessageRevert(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00log\x00-1\x00--format=%H%x00%B\x00abc123\x00--": {Stdout: "abc123\x00feat(core): add feature\n\ndetails\n"},
+		},
+	}
+	service := NewCommitService(&config.Config{Candidates: 1}, &ai.MockProvider{}, git.NewRunnerWithExecutor(mock))
+
+	subject, body, err := service.OperationMessage(context.Background(), git.Operation{Kind: git.OperationRevert, Head: "abc123"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if subject != "revert: feat(core): add feature" || body != "This reverts commit abc123." {
+		t.Fatalf("unexpected message: %q %q", subject, body)
+	}
+}
+
+func TestCommitService_OperationMessageMerge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "MERGE_MSG")
+	if err := os.WriteFile(path, []byte("Merge branch 'feature'\n\n# Conflicts:\n#\ta.go\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00rev-parse\x00--git-path\x00MERGE_MSG":               {Stdout: path + "\n"},
+			"git\x00log\x00--format=%H%x00%B%x1e\x00HEAD..def456\x00--": {Stdout: "def456\x00feat: add a\x1e"},
+		},
+	}
+	provider := &ai.MockProvider{Output: "- add a"}
+	service := NewCommitService(&config.Config{Candidates: 1}, provider, git.NewRunnerWithExecutor(mock))
+
+	subject, body, err := service.OperationMessage(context.Background(), git.Operation{Kind: git.OperationMerge, Head: "def456"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if subject != "Merge branch 'feature'" || body != "- add a\n\nResolved conflicts:\n- a.go" {
+		t.Fatalf("unexpected message: %q %q", subject, body)
+	}
+
+	provider.Err = errors.New("boom")
+	subject, body, err = service.OperationMessage(context.Background(), git.Operation{Kind: git.OperationMerge, Head: "def456"}, "")
+	if err == nil || subject != "Merge branch 'feature'" || body != "Resolved conflicts:\n- a.go" {
+		t.Fatalf("expected fallback message with error, got %q %q %v", subject, body, err)
+	}
+}
+
+func TestCommitService_DefaultTrailers(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00var\x00GIT_COMMITTER_IDENT": {Stdout: "Jane Doe <jane@example.com> 1700000000 +0000\n"},
+		},
+	}
+	cfg := &config.Config{Candidates: 1, Commit: config.CommitConfig{Trailers: []string{"Signed-off-by", "Reviewed-by: Bob <bob@example.com>"}}}
+	service := NewCommitService(cfg, &ai.MockProvider{}, git.NewRunnerWithExecutor(mock))
+
+	got := service.DefaultTrailers(context.Background())
+	want := []commit.Footer{
+		{Token: "Signed-off-by", Separator: ": ", Value: "Jane Doe <jane@example.com>"},
+		{Token: "Reviewed-by", Separator: ": ", Value: "Bob <bob@example.com>"},
+	}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("unexpected trailers: %#v", got)
+	}
+}
+
+func TestCommitService_FinalMessageFallback(t *testing.T) {
+	mock := &execx.MockRunner{Strict: true}
+	service := NewCommitService(&config.Config{Candidates: 1}, &ai.MockProvider{}, git.NewRunnerWithExecutor(mock))
+
+	c := &commit.ConventionalCommit{Subject: "feat: add x"}
+	trailers := []commit.Footer{
+		{Token: "Refs", Separator: " #", Value: "12"},
+		{Token: "Reviewed-by", Separator: ": ", Value: ""},
+	}
+	got, err := service.FinalMessage(context.Background(), c, trailers)
+	if err == nil {
+		t.Fatal("expected the interpret-trailers error to be returned")
+	}
+	if got != "feat: add x\n\nRefs #12" {
+		t.Fatalf("unexpected fallback message: %q", got)
+	}
+	if args := mock.Calls[0].Args; args[2] != "Refs: #12" {
+		t.Fatalf("unexpected trailer argument: %q", args)
+	}
+}
+
+func TestCommitService_CoAuthors(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00var\x00GIT_COMMITTER_IDENT":      {Stdout: "Me <me@example.com> 1700000000 +0000\n"},
+			"git\x00diff\x00--cached\x00--name-only": {Stdout: "a.go\n"},
+			"git\x00log\x00--max-count=200\x00--format=%aN <%aE>\x00--\x00a.go": {
+				Stdout: "Me <me@example.com>\nBob <bob@example.com>\nAnn <ann@example.com>\n",
+			},
+		},
+	}
+	cfg := &config.Config{Candidates: 1, CoAuthors: []string{"Ann <ANN@example.com>"}}
+	service := NewCommitService(cfg, &ai.MockProvider{}, git.NewRunnerWithExecutor(mock))
+
+	got, err := service.CoAuthors(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []CoAuthor{{Ident: "Ann <ANN@example.com>", Configured: true}, {Ident: "Bob <bob@example.com>"}}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("unexpected co-authors: %#v", got)
+	}
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// draftFile is where the draft is kept, relative to the git directory.
+const draftFile = "cx/draft.json"
+
+// Draft is an unfinished commit message, saved so that it survives a failed
+// commit, an accidental Ctrl+C or a closed terminal.
+type Draft struct {
+	Tree         string   `json:"tree"`           // staged tree the draft was written for
+	Head         string   `json:"head,omitempty"` // commit the changes were staged on
+	Type         string   `json:"type,omitempty"`
+	Scope        string   `json:"scope,omitempty"`
+	Emoji        string   `json:"emoji,omitempty"`
+	Subject      string   `json:"subject,omitempty"`
+	Breaking     bool     `json:"breaking,omitempty"`
+	BreakingNote string   `json:"breakingNote,omitempty"`
+	Body         string   `json:"body,omitempty"`
+	Footer       string   `json:"footer,omitempty"` // trailers, one "Token: value" per line
+	Candidates   []string `json:"candidates,omitempty"`
+	Rationales   []string `json:"rationales,omitempty"` // parallel to Candidates
+}
+
+// draftLocation returns the draft file, the staged tree and HEAD. They are
+// looked up once; the staged changes do not change while git-cx runs.
+func (s *CommitService) draftLocation(ctx context.Context) (string, string, string, error) {
+	if s.draftPath == "" {
+		tree, err := s.git.StagedTree(ctx)
+		if err != nil {
+			return "", "", "", err
+		}
+		path, err := s.git.GitPath(ctx, draftFile)
+		if err != nil {
+			return "", "", "", err
+		}
+		if tree == "" || path == "" {
+			return "", "", "", errors.New("draft: git directory not found")
+		}
+		s.draftPath, s.draftTree, s.draftHead = path, tree, s.git.Head(ctx)
+	}
+	return s.draftPath, s.draftTree, s.draftHead, nil
+}
+
+// SaveDraft stores d for the staged changes, replacing the previous draft.
+func (s *CommitService) SaveDraft(ctx context.Context, d Draft) error {
+	path, tree, head, err := s.draftLocation(ctx)
+	if err != nil {
+		return err
+	}
+	d.Tree, d.Head = tree, head
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode draft: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("save draft: %w", err)
+	}
+	// Write and rename so that a crash never leaves half a draft behind.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("save draft: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("save draft: %w", err)
+	}
+	return nil
+}
+
+// LoadDraft returns the saved draft if it was written for the changes staged
+// now and holds a subject or candidates worth restoring. A draft from before
+// HEAD last moved is removed: its message was most likely committed by git
+// after the prepare-commit-msg hook wrote it (see SetMessageFile).
+func (s *CommitService) LoadDraft(ctx context.Context) (Draft, bool) {
+	path, tree, head, err := s.draftLocation(ctx)
+	if err != nil {
+		return Draft{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Draft{}, false
+	}
+	var d Draft
+	if err := json.Unmarshal(data, &d); err != nil {
+		return Draft{}, false
+	}
+	if d.Head != head {
+		_ = s.ClearDraft(ctx)
+		return Draft{}, false
+	}
+	if d.Tree != tree {
+		return Draft{}, false
+	}
+	if d.Subject == "" && len(d.Candidates) == 0 {
+		return Draft{}, false
+	}
+	return d, true
+}
+
+// ClearDraft removes the saved draft, e.g. once the commit succeeded.
+func (s *CommitService) ClearDraft(ctx context.Context) error {
+	path, _, _, err := s.draftLocation(ctx)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove draft: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hayatosc/git-cx/internal/ai"
+	"github.com/hayatosc/git-cx/internal/config"
+	"github.com/hayatosc/git-cx/internal/execx"
+	"github.com/hayatosc/git-cx/internal/git"
+)
+
+func newDraftService(path, tree string) *CommitService {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00write-tree": {Stdout: tree + "\n"},
+			"git\x00rev-parse\x00--git-path\x00cx/draft.json": {Stdout: path + "\n"},
+		},
+	}
+	return NewCommitService(&config.Config{Candidates: 1}, &ai.MockProvider{}, git.NewRunnerWithExecutor(mock))
+}
+
+func TestCommitService_Draft(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cx", "draft.json")
+	service := newDraftService(path, "abc")
+
+	if _, ok := service.LoadDraft(ctx); ok {
+		t.Fatal("expected no draft before saving")
+	}
+	want := Draft{Type: "feat", Scope: "core", Subject: "add x", Body: "body", Footer: "Refs: #1", Candidates: []string{"feat(core): add x"}}
+	if err := service.SaveDraft(ctx, want); err != nil {
+		t.Fatalf("SaveDraft error: %v", err)
+	}
+	got, ok := service.LoadDraft(ctx)
+	if !ok || got.Tree != "abc" || got.Subject != "add x" || got.Footer != "Refs: #1" || len(got.Candidates) != 1 {
+		t.Fatalf("LoadDraft() = %#v, %v", got, ok)
+	}
+
+	if _, ok := newDraftService(path, "def").LoadDraft(ctx); ok {
+		t.Fatal("a draft for other staged changes must not be offered")
+	}
+
+	if err := service.ClearDraft(ctx); err != nil {
+		t.Fatalf("ClearDraft error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected the draft to be removed, got %v", err)
+	}
+	if err := service.ClearDraft(ctx); err != nil {
+		t.Fatalf("ClearDraft without a draft: %v", err)
+	}
+}
+
+func TestCommitService_DraftDroppedOnceHeadMoves(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "draft.json")
+	if err := newDraftService(path, "abc").SaveDraft(ctx, Draft{Subject: "add x"}); err != nil {
+		t.Fatalf("SaveDraft error: %v", err)
+	}
+
+	service := newDraftService(path, "abc")
+	service.git = git.NewRunnerWithExecutor(&execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00write-tree": {Stdout: "abc\n"},
+			"git\x00rev-parse\x00--git-path\x00cx/draft.json": {Stdout: path + "\n"},
+			"git\x00rev-parse\x00--verify\x00--quiet\x00HEAD": {Stdout: "1234\n"},
+		},
+	})
+	if _, ok := service.LoadDraft(ctx); ok {
+		t.Fatal("a draft from before the last commit must not be offered")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected the stale draft to be removed, got %v", err)
+	}
+}
+
+func TestCommitService_DraftWithoutContentIsNotOffered(t *testing.T) {
+	ctx := context.Background()
+	service := newDraftService(filepath.Join(t.TempDir(), "draft.json"), "abc")
+	if err := service.SaveDraft(ctx, Draft{Type: "feat", Scope: "core"}); err != nil {
+		t.Fatalf("SaveDraft error: %v", err)
+	}
+	if _, ok := service.LoadDraft(ctx); ok {
+		t.Fatal("a draft with neither subject nor candidates should not be offered")
+	}
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hayatosc/git-cx/internal/commit"
+)
+
+// editHint follows the message in the file opened by EditorCommand, as in
+// git's own commit template.
+const editHint = `# Please enter the commit message for your changes. Lines starting
+# with '#' will be ignored, and an empty message aborts the edit.
+`
+
+// EditorCommand writes message to a temporary file, followed by stat as
+// comments, and returns the command that opens the file in git's editor
+// (GIT_EDITOR, core.editor, VISUAL or EDITOR) together with its path. Read
+// the result back with ReadEditedMessage once the command has exited.
+func (s *CommitService) EditorCommand(ctx context.Context, message, stat string) (*exec.Cmd, string, error) {
+	editor, err := s.git.Editor(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(message, "\n") + "\n\n" + editHint)
+	if stat = strings.TrimRight(stat, "\n"); stat != "" {
+		sb.WriteString("#\n# Changes to be committed:\n")
+		for _, line := range strings.Split(stat, "\n") {
+			sb.WriteString("#" + line + "\n")
+		}
+	}
+
+	f, err := os.CreateTemp("", "git-cx-*.COMMIT_EDITMSG")
+	if err != nil {
+		return nil, "", fmt.Errorf("create message file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(sb.String()); err != nil {
+		os.Remove(f.Name())
+		return nil, "", fmt.Errorf("write message file: %w", err)
+	}
+	// Like git, run the editor through the shell so it may carry arguments.
+	return exec.Command("sh", "-c", editor+` "$@"`, editor, f.Name()), f.Name(), nil
+}
+
+// ReadEditedMessage returns the message saved in the file written by
+// EditorCommand, without comment lines, and removes the file.
+func ReadEditedMessage(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	os.Remove(path)
+	if err != nil {
+		return "", fmt.Errorf("read message file: %w", err)
+	}
+	return commit.CleanMessage(string(data)), nil
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hayatosc/git-cx/internal/ai"
+	"github.com/hayatosc/git-cx/internal/config"
+	"github.com/hayatosc/git-cx/internal/execx"
+	"github.com/hayatosc/git-cx/internal/git"
+)
+
+func TestCommitService_EditorCommand(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00var\x00GIT_EDITOR": {Stdout: "vim -f\n"},
+		},
+	}
+	service := NewCommitService(&config.Config{Candidates: 1}, &ai.MockProvider{}, git.NewRunnerWithExecutor(mock))
+
+	cmd, path, err := service.EditorCommand(context.Background(), "feat: add x\n\nbody", " main.go | 2 +-\n 1 file changed\n")
+	if err != nil {
+		t.Fatalf("EditorCommand error: %v", err)
+	}
+	if got := strings.Join(cmd.Args, " "); got != `sh -c vim -f "$@" vim -f `+path {
+		t.Fatalf("unexpected command: %q", got)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), "feat: add x\n\nbody\n\n# Please enter") || !strings.Contains(string(data), "\n# main.go | 2 +-\n# 1 file changed\n") {
+		t.Fatalf("unexpected file:\n%s", data)
+	}
+
+	if err := os.WriteFile(path, append([]byte("fix: edited\n\nnew body\n"), data[strings.Index(string(data), "# Please"):]...), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := ReadEditedMessage(path)
+	if err != nil || msg != "fix: edited\n\nnew body" {
+		t.Fatalf("ReadEditedMessage() = %q, %v", msg, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected message file to be removed, got %v", err)
+	}
+}
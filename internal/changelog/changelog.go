@@ -0,0 +1,231 @@
+package changelog
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hayatosc/git-cx/internal/commit"
+	"github.com/hayatosc/git-cx/internal/git"
+	"github.com/hayatosc/git-cx/internal/semver"
+)
+
+// Entry is a single changelog line derived from a Conventional Commit.
+type Entry struct {
+	Hash         string `json:"hash"`
+	Type         string `json:"type"`
+	Scope        string `json:"scope,omitempty"`
+	Subject      string `json:"subject"`
+	Breaking     bool   `json:"breaking"`
+	BreakingNote string `json:"breakingNote,omitempty"`
+}
+
+// Section groups entries under a Keep a Changelog heading.
+type Section struct {
+	Title   string  `json:"title"`
+	Entries []Entry `json:"entries"`
+}
+
+// Changelog is the structured changelog for one release.
+type Changelog struct {
+	Version  string    `json:"version"`
+	Date     string    `json:"date,omitempty"`
+	Breaking []Entry   `json:"breaking"`
+	Sections []Section `json:"sections"`
+}
+
+// Options controls Build.
+type Options struct {
+	Version string // heading version; "Unreleased" when empty
+	Date    string // release date shown next to the version
+	All     bool   // include docs, style, test, build, ci and chore commits
+	// Types are the configured commit types (cx.types.*). Those without a
+	// section of their own are listed under "Other" even without All.
+	Types commit.Types
+}
+
+type sectionDef struct {
+	title string
+	types []string
+	minor bool // only shown with Options.All
+}
+
+var sections = []sectionDef{
+	{title: "Added", types: []string{"feat"}},
+	{title: "Fixed", types: []string{"fix"}},
+	{title: "Changed", types: []string{"perf", "refactor", "revert"}},
+	{title: "Documentation", types: []string{"docs"}, minor: true},
+	{title: "Maintenance", types: []string{"style", "test", "build", "ci", "chore"}, minor: true},
+}
+
+// Build parses commits (newest first, as returned by git log) and groups them
+// by section and scope. Commits that are not Conventional Commits are skipped.
+func Build(commits []git.LogEntry, opts Options) Changelog {
+	cl := Changelog{Version: opts.Version, Date: opts.Date}
+	if cl.Version == "" {
+		cl.Version = "Unreleased"
+	}
+	grouped := make(map[string][]Entry)
+	for i := len(commits) - 1; i >= 0; i-- {
+		e, ok := parseEntry(commits[i])
+		if !ok {
+			continue
+		}
+		if e.Breaking {
+			cl.Breaking = append(cl.Breaking, e)
+		}
+		grouped[e.Type] = append(grouped[e.Type], e)
+	}
+	for _, def := range sections {
+		if def.minor && !opts.All {
+			continue
+		}
+		var entries []Entry
+		for _, t := range def.types {
+			entries = append(entries, grouped[t]...)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		cl.Sections = append(cl.Sections, Section{Title: def.title, Entries: sortByScope(entries)})
+	}
+	other := otherEntries(grouped, func(t string) bool {
+		_, configured := opts.Types.Lookup(t)
+		return opts.All || configured
+	})
+	if len(other) > 0 {
+		cl.Sections = append(cl.Sections, Section{Title: "Other", Entries: sortByScope(other)})
+	}
+	return cl
+}
+
+// Bump returns the version increment the commits require: major for
+// breaking changes, otherwise the largest bump configured for their types
+// (minor for feat, patch for fix and perf with the built-in types).
+func Bump(commits []git.LogEntry, types commit.Types) semver.Bump {
+	if len(types) == 0 {
+		types = commit.DefaultTypes()
+	}
+	bump := semver.BumpNone
+	for _, c := range commits {
+		e, ok := parseEntry(c)
+		if !ok {
+			continue
+		}
+		if e.Breaking {
+			return semver.BumpMajor
+		}
+		if t, ok := types.Lookup(e.Type); ok {
+			bump = max(bump, typeBump(t.Bump))
+		}
+	}
+	return bump
+}
+
+func typeBump(b string) semver.Bump {
+	switch b {
+	case commit.BumpMajor:
+		return semver.BumpMajor
+	case commit.BumpMinor:
+		return semver.BumpMinor
+	case commit.BumpPatch:
+		return semver.BumpPatch
+	}
+	return semver.BumpNone
+}
+
+// otherEntries returns the entries of the types without a section that
+// include accepts.
+func otherEntries(grouped map[string][]Entry, include func(string) bool) []Entry {
+	var types []string
+	for t := range grouped {
+		known := slices.ContainsFunc(sections, func(def sectionDef) bool { return slices.Contains(def.types, t) })
+		if !known && include(t) {
+			types = append(types, t)
+		}
+	}
+	sort.Strings(types)
+	var entries []Entry
+	for _, t := range types {
+		entries = append(entries, grouped[t]...)
+	}
+	return entries
+}
+
+// sortByScope orders entries by scope, keeping the commit order within one.
+func sortByScope(entries []Entry) []Entry {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Scope < entries[j].Scope })
+	return entries
+}
+
+func parseEntry(c git.LogEntry) (Entry, bool) {
+	parsed, err := commit.Parse(c.Message)
+	if err != nil {
+		return Entry{}, false
+	}
+	return Entry{
+		Hash:         c.Hash,
+		Type:         parsed.Type,
+		Scope:        parsed.Scope,
+		Subject:      strings.TrimSpace(parsed.Subject),
+		Breaking:     parsed.IsBreaking(),
+		BreakingNote: parsed.BreakingNote(),
+	}, true
+}
+
+// Markdown renders the changelog in Keep a Changelog style.
+func (c Changelog) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("## ")
+	if c.Version == "Unreleased" {
+		sb.WriteString("[Unreleased]")
+	} else {
+		sb.WriteString("[" + c.Version + "]")
+	}
+	if c.Date != "" {
+		sb.WriteString(" - " + c.Date)
+	}
+	sb.WriteString("\n")
+
+	if len(c.Breaking) > 0 {
+		sb.WriteString("\n### ⚠ BREAKING CHANGES\n\n")
+		for _, e := range c.Breaking {
+			sb.WriteString(markdownLine(e))
+			if e.BreakingNote != "" {
+				for _, line := range strings.Split(e.BreakingNote, "\n") {
+					sb.WriteString("  " + line + "\n")
+				}
+			}
+		}
+	}
+	for _, s := range c.Sections {
+		fmt.Fprintf(&sb, "\n### %s\n\n", s.Title)
+		for _, e := range s.Entries {
+			sb.WriteString(markdownLine(e))
+		}
+	}
+	if len(c.Breaking) == 0 && len(c.Sections) == 0 {
+		sb.WriteString("\nNo notable changes.\n")
+	}
+	return sb.String()
+}
+
+func markdownLine(e Entry) string {
+	line := "- "
+	if e.Scope != "" {
+		line += "**" + e.Scope + ":** "
+	}
+	line += e.Subject
+	if e.Hash != "" {
+		line += " (" + shortHash(e.Hash) + ")"
+	}
+	return line + "\n"
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
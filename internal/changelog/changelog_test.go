@@ -0,0 +1,136 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hayatosc/git-cx/internal/commit"
+	"github.com/hayatosc/git-cx/internal/git"
+	"github.com/hayatosc/git-cx/internal/semver"
+)
+
+var testCommits = []git.LogEntry{
+	{Hash: "5555555aaaa", Message: "docs: update readme"},
+	{Hash: "4444444aaaa", Message: "Merge branch 'topic'"},
+	{Hash: "3333333aaaa", Message: "feat(api)!: drop v1 endpoints\n\nBREAKING CHANGE: clients must use /v2\nand re-authenticate.\nRefs: #9"},
+	{Hash: "2222222aaaa", Message: "fix(cli): handle empty config"},
+	{Hash: "1111111aaaa", Message: "feat: add billing retries"},
+}
+
+func TestBuild_GroupsByTypeAndScope(t *testing.T) {
+	cl := Build(testCommits, Options{})
+	if cl.Version != "Unreleased" {
+		t.Fatalf("unexpected version: %q", cl.Version)
+	}
+	if len(cl.Sections) != 2 || cl.Sections[0].Title != "Added" || cl.Sections[1].Title != "Fixed" {
+		t.Fatalf("unexpected sections: %+v", cl.Sections)
+	}
+	added := cl.Sections[0].Entries
+	if len(added) != 2 || added[0].Scope != "" || added[1].Scope != "api" {
+		t.Fatalf("entries not grouped by scope: %+v", added)
+	}
+	if len(cl.Breaking) != 1 || cl.Breaking[0].BreakingNote != "clients must use /v2\nand re-authenticate." {
+		t.Fatalf("unexpected breaking changes: %+v", cl.Breaking)
+	}
+}
+
+func TestBuild_AllIncludesMinorSections(t *testing.T) {
+	cl := Build(testCommits, Options{All: true})
+	if len(cl.Sections) != 3 || cl.Sections[2].Title != "Documentation" {
+		t.Fatalf("unexpected sections: %+v", cl.Sections)
+	}
+}
+
+func TestBuild_ConfiguredTypesUnderOther(t *testing.T) {
+	commits := append([]git.LogEntry{
+		{Hash: "9999999aaaa", Message: "sec(tls): pin ciphers"},
+		{Hash: "8888888aaaa", Message: "sec: rotate signing keys"},
+		{Hash: "7777777aaaa", Message: "sec(auth): expire sessions"},
+		{Hash: "6666666aaaa", Message: "wip: try something"},
+	}, testCommits...)
+	types := append(commit.DefaultTypes(), commit.Type{Name: "sec", Description: "Security fixes"})
+
+	cl := Build(commits, Options{Types: types})
+	last := cl.Sections[len(cl.Sections)-1]
+	if last.Title != "Other" || len(last.Entries) != 3 {
+		t.Fatalf("expected the configured type under Other, got %+v", cl.Sections)
+	}
+	var subjects []string
+	for _, e := range last.Entries {
+		subjects = append(subjects, e.Subject)
+	}
+	if got := strings.Join(subjects, ", "); got != "rotate signing keys, expire sessions, pin ciphers" {
+		t.Fatalf("expected Other sorted by scope, got %s", got)
+	}
+
+	cl = Build(commits, Options{Types: types, All: true})
+	last = cl.Sections[len(cl.Sections)-1]
+	if last.Title != "Other" || len(last.Entries) != 4 {
+		t.Fatalf("expected unknown types under Other with All, got %+v", last)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	got := Build(testCommits, Options{Version: "v1.2.0", Date: "2026-10-19"}).Markdown()
+	want := `## [v1.2.0] - 2026-10-19
+
+### ⚠ BREAKING CHANGES
+
+- **api:** drop v1 endpoints (3333333)
+  clients must use /v2
+  and re-authenticate.
+
+### Added
+
+- add billing retries (1111111)
+- **api:** drop v1 endpoints (3333333)
+
+### Fixed
+
+- **cli:** handle empty config (2222222)
+`
+	if got != want {
+		t.Fatalf("unexpected markdown:\n%s", got)
+	}
+}
+
+func TestMarkdown_Empty(t *testing.T) {
+	got := Build(nil, Options{}).Markdown()
+	if got != "## [Unreleased]\n\nNo notable changes.\n" {
+		t.Fatalf("unexpected markdown:\n%q", got)
+	}
+}
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		messages []string
+		want     semver.Bump
+	}{
+		{[]string{"docs: a", "chore: b"}, semver.BumpNone},
+		{[]string{"docs: a", "perf: b"}, semver.BumpPatch},
+		{[]string{"fix: a", "feat(x): b"}, semver.BumpMinor},
+		{[]string{"fix: a", "refactor!: b"}, semver.BumpMajor},
+		{[]string{"fix: a\n\nBREAKING CHANGE: removed flag"}, semver.BumpMajor},
+	}
+	for _, tt := range tests {
+		var commits []git.LogEntry
+		for _, m := range tt.messages {
+			commits = append(commits, git.LogEntry{Message: m})
+		}
+		if got := Bump(commits, nil); got != tt.want {
+			t.Errorf("Bump(%v) = %s, want %s", tt.messages, got, tt.want)
+		}
+	}
+}
+
+func TestBump_CustomTypes(t *testing.T) {
+	types := append(commit.DefaultTypes(), commit.Type{Name: "sec", Bump: commit.BumpPatch}, commit.Type{Name: "deps", Bump: commit.BumpNone})
+	commits := []git.LogEntry{{Message: "deps: bump x"}}
+	if got := Bump(commits, types); got != semver.BumpNone {
+		t.Fatalf("deps bump = %s, want none", got)
+	}
+	commits = append(commits, git.LogEntry{Message: "sec(auth): rotate keys"})
+	if got := Bump(commits, types); got != semver.BumpPatch {
+		t.Fatalf("sec bump = %s, want patch", got)
+	}
+}
@@ -0,0 +1,149 @@
+package commit
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+var bulletPattern = regexp.MustCompile(`^(\s*)([-*+•]|\d+[.)])\s+(.*)$`)
+
+// FormatBody tidies a commit body for git log: trailing whitespace is
+// trimmed, runs of blank lines collapse into one, and "*", "+" and "•"
+// bullets become "-" when normalizeBullets is set. With wrap > 0, each
+// paragraph or list item that has a line wider than wrap columns is
+// reflowed to fit; the rest keep their line breaks. Fenced and indented
+// code blocks are left alone.
+func FormatBody(body string, wrap int, normalizeBullets bool) string {
+	var out []string
+	var cur *bodyItem
+	flush := func() {
+		if cur != nil {
+			out = append(out, cur.render(wrap)...)
+			cur = nil
+		}
+	}
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			flush()
+			inFence = !inFence
+			out = append(out, line)
+		case inFence:
+			out = append(out, line)
+		case trimmed == "":
+			flush()
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+		case bulletPattern.MatchString(line):
+			flush()
+			m := bulletPattern.FindStringSubmatch(line)
+			marker := m[2]
+			if normalizeBullets && (marker == "*" || marker == "+" || marker == "•") {
+				marker = "-"
+			}
+			prefix := m[1] + marker + " "
+			cur = &bodyItem{prefix: prefix, indent: strings.Repeat(" ", runewidth.StringWidth(prefix)), lines: []string{prefix + m[3]}, texts: []string{m[3]}}
+		case cur != nil:
+			cur.lines = append(cur.lines, line)
+			cur.texts = append(cur.texts, trimmed)
+		case strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "    "):
+			out = append(out, line) // indented code
+		default:
+			cur = &bodyItem{lines: []string{line}, texts: []string{trimmed}}
+		}
+	}
+	flush()
+	return strings.Trim(strings.Join(out, "\n"), "\n")
+}
+
+// bodyItem is a plain paragraph or a list item with its continuation lines.
+type bodyItem struct {
+	prefix string   // bullet marker with its indentation, "" for paragraphs
+	indent string   // indentation of continuation lines
+	lines  []string // lines as written, with the marker normalised
+	texts  []string // line contents without marker or indentation
+}
+
+func (b *bodyItem) render(wrap int) []string {
+	if wrap <= 0 || !b.overflows(wrap) {
+		return b.lines
+	}
+	return wrapWords(strings.Fields(strings.Join(b.texts, " ")), wrap, b.prefix, b.indent)
+}
+
+func (b *bodyItem) overflows(wrap int) bool {
+	for _, line := range b.lines {
+		if runewidth.StringWidth(line) > wrap {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapWords fills lines up to width columns, starting the first with first
+// and the others with rest. Words with wide (CJK) characters may break
+// between those characters; other words are never split, so a long URL
+// gets a line of its own.
+func wrapWords(words []string, width int, first, rest string) []string {
+	var lines []string
+	line, empty := first, true
+	for _, word := range words {
+		for i, tok := range splitWide(word) {
+			sep := " "
+			if i > 0 {
+				sep = ""
+			}
+			if !empty && runewidth.StringWidth(line)+len(sep)+runewidth.StringWidth(tok) > width {
+				lines = append(lines, line)
+				line, empty, sep = rest, true, ""
+			}
+			if empty {
+				sep = ""
+			}
+			line += sep + tok
+			empty = false
+		}
+	}
+	return append(lines, line)
+}
+
+// splitWide splits word before and after every wide character, keeping
+// runs of narrow characters together.
+func splitWide(word string) []string {
+	var toks []string
+	var run strings.Builder
+	for _, r := range word {
+		if runewidth.RuneWidth(r) < 2 {
+			run.WriteRune(r)
+			continue
+		}
+		if run.Len() > 0 {
+			toks = append(toks, run.String())
+			run.Reset()
+		}
+		toks = append(toks, string(r))
+	}
+	if run.Len() > 0 {
+		toks = append(toks, run.String())
+	}
+	return toks
+}
+
+// Width returns the number of terminal columns s takes, with wide (CJK)
+// characters and emoji counting two. Every length limit on a message is
+// measured this way.
+func Width(s string) int {
+	return runewidth.StringWidth(s)
+}
+
+// truncateWidth cuts s to at most width display columns without splitting
+// a character.
+func truncateWidth(s string, width int) string {
+	return runewidth.Truncate(s, width, "")
+}
@@ -0,0 +1,57 @@
+package commit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatBody_WrapsLongParagraph(t *testing.T) {
+	body := strings.Repeat("word ", 30) + "end"
+	got := FormatBody(body, 72, true)
+	for _, line := range strings.Split(got, "\n") {
+		if len(line) > 72 {
+			t.Fatalf("line longer than 72 columns: %q", line)
+		}
+	}
+	if strings.Join(strings.Fields(got), " ") != strings.TrimSpace(body) {
+		t.Fatalf("wrapping changed the words:\n%s", got)
+	}
+}
+
+func TestFormatBody_Bullets(t *testing.T) {
+	body := "Changes:  \n* add the retry loop for failed webhook deliveries so that transient errors do not drop events\n+ log attempts\n\n\n\n• keep order"
+	want := "Changes:\n- add the retry loop for failed webhook deliveries so that transient\n  errors do not drop events\n- log attempts\n\n- keep order"
+	if got := FormatBody(body, 72, true); got != want {
+		t.Fatalf("unexpected body:\n%s\nwant:\n%s", got, want)
+	}
+	if got := FormatBody("* keep star", 72, false); got != "* keep star" {
+		t.Fatalf("bullets should be kept without normalisation: %q", got)
+	}
+}
+
+func TestFormatBody_KeepsShortLinesAndCode(t *testing.T) {
+	body := "Short line one\nshort line two\n\n```\n" + strings.Repeat("x", 100) + "\n```\n\n    " + strings.Repeat("y", 100)
+	if got := FormatBody(body, 72, true); got != body {
+		t.Fatalf("body should be unchanged:\n%s", got)
+	}
+}
+
+func TestFormatBody_WideCharacters(t *testing.T) {
+	body := strings.Repeat("日本語の説明文", 10)
+	got := FormatBody(body, 20, true)
+	for _, line := range strings.Split(got, "\n") {
+		if w := len([]rune(line)) * 2; w > 20 {
+			t.Fatalf("line wider than 20 columns: %q", line)
+		}
+	}
+	if strings.ReplaceAll(got, "\n", "") != body {
+		t.Fatalf("wrapping changed the text:\n%s", got)
+	}
+}
+
+func TestFormat_TruncatesByWidth(t *testing.T) {
+	c := &ConventionalCommit{Type: "fix", Subject: "日本語の件名"}
+	if got := Format(c, FormatOptions{MaxSubjectLength: 5}); got != "fix: 日本" {
+		t.Fatalf("unexpected header: %q", got)
+	}
+}
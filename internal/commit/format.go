@@ -1,57 +1,123 @@
 package commit
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatOptions controls Format and BuildMessage.
+type FormatOptions struct {
+	Types            Types // known types; the built-in types when empty
+	UseEmoji         bool  // add the type emoji when the commit has no Emoji
+	EmojiPlacement   string
+	EmojiFormat      string
+	MaxSubjectLength int  // in display columns; 0 means no limit
+	BodyWrap         int  // body width in display columns; 0 disables wrapping
+	NormalizeBullets bool // rewrite "*", "+" and "•" bullets as "-"
+}
 
 // Format returns the full commit message string from a ConventionalCommit.
-func Format(c *ConventionalCommit, useEmoji bool, maxSubjectLen int) string {
+func Format(c *ConventionalCommit, opts FormatOptions) string {
 	var sb strings.Builder
 
-	// Build header: type(scope)!: subject
-	sb.WriteString(c.Type)
-	if c.Scope != "" {
-		sb.WriteString("(")
-		sb.WriteString(c.Scope)
-		sb.WriteString(")")
+	subject := c.Subject
+	if opts.MaxSubjectLength > 0 {
+		subject = truncateWidth(subject, opts.MaxSubjectLength)
 	}
-	if c.Breaking {
-		sb.WriteString("!")
+	emoji := c.Emoji
+	if emoji == "" && opts.UseEmoji {
+		if t, ok := opts.Types.orDefault().Lookup(c.Type); ok {
+			emoji = t.Emoji
+		}
+	}
+	if emoji != "" {
+		emoji = renderEmoji(emoji, opts.EmojiFormat)
 	}
-	sb.WriteString(": ")
 
-	subject := c.Subject
-	if maxSubjectLen > 0 && len(subject) > maxSubjectLen {
-		subject = subject[:maxSubjectLen]
+	// Build header: type(scope)!: subject, with the emoji placed as configured
+	scope := ""
+	if c.Scope != "" {
+		scope = "(" + c.Scope + ")"
 	}
-	if useEmoji {
-		if emoji, ok := typeEmojis[c.Type]; ok {
-			sb.WriteString(emoji)
-			sb.WriteString(" ")
-		}
+	bang := ""
+	if c.Breaking {
+		bang = "!"
+	}
+	switch {
+	case emoji == "":
+		sb.WriteString(c.Type + scope + bang + ": " + subject)
+	case opts.EmojiPlacement == EmojiPlacementPrefix:
+		sb.WriteString(emoji + " " + c.Type + scope + bang + ": " + subject)
+	case opts.EmojiPlacement == EmojiPlacementOnly && scope+bang == "":
+		sb.WriteString(emoji + " " + subject)
+	case opts.EmojiPlacement == EmojiPlacementOnly:
+		sb.WriteString(emoji + " " + scope + bang + ": " + subject)
+	default:
+		sb.WriteString(c.Type + scope + bang + ": " + emoji + " " + subject)
 	}
-	sb.WriteString(subject)
 
-	if c.Body != "" {
+	if body := opts.body(c.Body); body != "" {
 		sb.WriteString("\n\n")
-		sb.WriteString(c.Body)
+		sb.WriteString(body)
 	}
 
-	if c.Footer != "" {
+	if footer := c.footerText(); footer != "" {
 		sb.WriteString("\n\n")
-		sb.WriteString(c.Footer)
+		sb.WriteString(footer)
 	}
 
 	return sb.String()
 }
 
-// BuildMessage decides whether to format or use raw subject.
-func BuildMessage(c *ConventionalCommit, useEmoji bool, maxSubjectLen int) string {
-	if isConventionalHeader(c.Subject) {
-		return buildRawMessage(c.Subject, c.Body, c.Footer)
+// footerText joins the free-form footer and the structured trailers into one
+// paragraph.
+func (c *ConventionalCommit) footerText() string {
+	footers := FormatFooters(c.Footers)
+	switch {
+	case c.Footer == "":
+		return footers
+	case footers == "":
+		return c.Footer
+	default:
+		return c.Footer + "\n" + footers
+	}
+}
+
+// Revert returns the commit that reverts the commit with the given header
+// and hash, using git's "This reverts commit" body.
+func Revert(header, hash string) *ConventionalCommit {
+	return &ConventionalCommit{
+		Type:    "revert",
+		Subject: header,
+		Body:    fmt.Sprintf("This reverts commit %s.", hash),
+	}
+}
+
+// BuildMessage decides whether to format or use raw subject. A subject that
+// is already a full header (an AI candidate or an "auto" manual entry) is
+// re-rendered only to apply c.Emoji and the emoji options.
+func BuildMessage(c *ConventionalCommit, opts FormatOptions) string {
+	if h, ok := conventionalHeader(c.Subject, opts.Types); ok {
+		if c.Emoji == "" && h.Emoji == "" && !opts.UseEmoji {
+			return buildRawMessage(MarkBreaking(c.Subject, c.Breaking || h.Breaking), opts.body(c.Body), c.footerText())
+		}
+		full := *c
+		full.Type, full.Scope, full.Subject = h.Type, h.Scope, h.Subject
+		full.Breaking = c.Breaking || h.Breaking
+		if full.Emoji == "" {
+			full.Emoji = h.Emoji
+		}
+		opts.MaxSubjectLength = 0
+		return Format(&full, opts)
 	}
 	if c.Type == "" {
-		return buildRawMessage(c.Subject, c.Body, c.Footer)
+		return buildRawMessage(c.Subject, opts.body(c.Body), c.footerText())
 	}
-	return Format(c, useEmoji, maxSubjectLen)
+	return Format(c, opts)
+}
+
+func (o FormatOptions) body(body string) string {
+	return FormatBody(body, o.BodyWrap, o.NormalizeBullets)
 }
 
 func buildRawMessage(subject, body, footer string) string {
@@ -65,32 +131,13 @@ func buildRawMessage(subject, body, footer string) string {
 	return result
 }
 
-func isConventionalHeader(s string) bool {
-	for _, t := range CommitTypes {
-		if t == "auto" {
-			continue
-		}
-		if len(s) > len(t) && s[:len(t)] == t {
-			rest := s[len(t):]
-			if len(rest) > 0 && (rest[0] == '(' || rest[0] == ':' || rest[0] == '!') {
-				return true
-			}
-		}
+// conventionalHeader parses s when it is already a full header with one of
+// the known types, as AI candidates and "auto" manual entries are.
+func conventionalHeader(s string, types Types) (Header, bool) {
+	h, err := ParseHeader(s)
+	if err != nil {
+		return Header{}, false
 	}
-	return false
-}
-
-// typeEmojis maps commit types to gitmoji-style emojis.
-var typeEmojis = map[string]string{
-	"feat":     "✨",
-	"fix":      "🐛",
-	"docs":     "📝",
-	"style":    "💄",
-	"refactor": "♻️",
-	"perf":     "⚡️",
-	"test":     "✅",
-	"build":    "🔧",
-	"ci":       "👷",
-	"chore":    "🔨",
-	"revert":   "⏪",
+	_, ok := types.orDefault().Lookup(h.Type)
+	return h, ok
 }
@@ -8,7 +8,7 @@ func TestBuildMessage_ConventionalHeader(t *testing.T) {
 		Body:    "details",
 		Footer:  "Refs: #1",
 	}
-	got := BuildMessage(c, false, 72)
+	got := BuildMessage(c, FormatOptions{MaxSubjectLength: 72})
 	want := "feat(core): add feature\n\ndetails\n\nRefs: #1"
 	if got != want {
 		t.Fatalf("unexpected message:\n%s", got)
@@ -21,7 +21,7 @@ func TestBuildMessage_Format(t *testing.T) {
 		Scope:   "api",
 		Subject: "handle nil response",
 	}
-	got := BuildMessage(c, false, 72)
+	got := BuildMessage(c, FormatOptions{MaxSubjectLength: 72})
 	want := "fix(api): handle nil response"
 	if got != want {
 		t.Fatalf("unexpected message:\n%s", got)
@@ -35,9 +35,75 @@ func TestBuildMessage_EmptyType(t *testing.T) {
 		Body:    "details",
 		Footer:  "Refs: #1",
 	}
-	got := BuildMessage(c, false, 72)
+	got := BuildMessage(c, FormatOptions{MaxSubjectLength: 72})
 	want := "add feature\n\ndetails\n\nRefs: #1"
 	if got != want {
 		t.Fatalf("unexpected message:\n%s", got)
 	}
 }
+
+func TestRevert(t *testing.T) {
+	got := Format(Revert("feat(core): add feature", "abc123"), FormatOptions{})
+	want := "revert: feat(core): add feature\n\nThis reverts commit abc123."
+	if got != want {
+		t.Fatalf("unexpected message:\n%s", got)
+	}
+}
+
+func TestBuildMessage_BreakingHeader(t *testing.T) {
+	c := &ConventionalCommit{
+		Subject:  "feat(api): drop v1",
+		Breaking: true,
+		Footers:  []Footer{{Token: "BREAKING CHANGE", Separator: ": ", Value: "use v2"}},
+	}
+	got := BuildMessage(c, FormatOptions{MaxSubjectLength: 72})
+	want := "feat(api)!: drop v1\n\nBREAKING CHANGE: use v2"
+	if got != want {
+		t.Fatalf("unexpected message:\n%s", got)
+	}
+}
+
+func TestBuildMessage_CustomTypes(t *testing.T) {
+	types := append(DefaultTypes(), Type{Name: "sec", Description: "Security fixes", Emoji: "🔒", Bump: BumpPatch})
+	c := &ConventionalCommit{Type: "sec", Subject: "sec(auth): rotate keys"}
+	if got := BuildMessage(c, FormatOptions{Types: types}); got != "sec(auth): rotate keys" {
+		t.Fatalf("custom-type header not kept as is: %q", got)
+	}
+	c = &ConventionalCommit{Type: "sec", Subject: "rotate keys"}
+	if got := BuildMessage(c, FormatOptions{Types: types, UseEmoji: true}); got != "sec: 🔒 rotate keys" {
+		t.Fatalf("unexpected message: %q", got)
+	}
+	c = &ConventionalCommit{Type: "fix", Subject: "sec: rotate keys"}
+	if got := BuildMessage(c, FormatOptions{}); got != "fix: sec: rotate keys" {
+		t.Fatalf("unknown type treated as header: %q", got)
+	}
+}
+
+func TestFormat_EmojiPlacement(t *testing.T) {
+	c := &ConventionalCommit{Emoji: "🔒️", Type: "fix", Scope: "auth", Subject: "rotate keys"}
+	tests := []struct {
+		opts FormatOptions
+		want string
+	}{
+		{FormatOptions{}, "fix(auth): 🔒️ rotate keys"},
+		{FormatOptions{EmojiPlacement: EmojiPlacementPrefix}, "🔒️ fix(auth): rotate keys"},
+		{FormatOptions{EmojiPlacement: EmojiPlacementOnly, EmojiFormat: EmojiFormatShortcode}, ":lock: (auth): rotate keys"},
+	}
+	for _, tt := range tests {
+		if got := Format(c, tt.opts); got != tt.want {
+			t.Errorf("Format(%+v) = %q, want %q", tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestBuildMessage_HeaderWithEmoji(t *testing.T) {
+	c := &ConventionalCommit{Subject: "fix(auth): :lock: rotate keys", Body: "details"}
+	got := BuildMessage(c, FormatOptions{EmojiPlacement: EmojiPlacementPrefix})
+	if got != "🔒️ fix(auth): rotate keys\n\ndetails" {
+		t.Fatalf("unexpected message: %q", got)
+	}
+	c = &ConventionalCommit{Emoji: "🥅", Subject: "fix(auth): :lock: rotate keys"}
+	if got := BuildMessage(c, FormatOptions{EmojiFormat: EmojiFormatShortcode}); got != "fix(auth): :goal_net: rotate keys" {
+		t.Fatalf("chosen emoji should replace the AI one: %q", got)
+	}
+}
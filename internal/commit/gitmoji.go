@@ -0,0 +1,181 @@
+package commit
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Gitmoji is one entry of the gitmoji catalogue (https://gitmoji.dev).
+type Gitmoji struct {
+	Emoji       string
+	Code        string // shortcode without colons, e.g. "sparkles"
+	Description string
+	Type        string // closest Conventional Commits type, used for emoji-only headers
+}
+
+// Shortcode returns the emoji as ":code:".
+func (g Gitmoji) Shortcode() string {
+	return ":" + g.Code + ":"
+}
+
+// Gitmojis is the gitmoji catalogue in its upstream order.
+var Gitmojis = []Gitmoji{
+	{Emoji: "🎨", Code: "art", Description: "Improve structure / format of the code", Type: "style"},
+	{Emoji: "⚡️", Code: "zap", Description: "Improve performance", Type: "perf"},
+	{Emoji: "🔥", Code: "fire", Description: "Remove code or files", Type: "refactor"},
+	{Emoji: "🐛", Code: "bug", Description: "Fix a bug", Type: "fix"},
+	{Emoji: "🚑️", Code: "ambulance", Description: "Critical hotfix", Type: "fix"},
+	{Emoji: "✨", Code: "sparkles", Description: "Introduce new features", Type: "feat"},
+	{Emoji: "📝", Code: "memo", Description: "Add or update documentation", Type: "docs"},
+	{Emoji: "🚀", Code: "rocket", Description: "Deploy stuff", Type: "ci"},
+	{Emoji: "💄", Code: "lipstick", Description: "Add or update the UI and style files", Type: "style"},
+	{Emoji: "🎉", Code: "tada", Description: "Begin a project", Type: "chore"},
+	{Emoji: "✅", Code: "white_check_mark", Description: "Add, update, or pass tests", Type: "test"},
+	{Emoji: "🔒️", Code: "lock", Description: "Fix security or privacy issues", Type: "fix"},
+	{Emoji: "🔐", Code: "closed_lock_with_key", Description: "Add or update secrets", Type: "chore"},
+	{Emoji: "🔖", Code: "bookmark", Description: "Release / Version tags", Type: "chore"},
+	{Emoji: "🚨", Code: "rotating_light", Description: "Fix compiler / linter warnings", Type: "style"},
+	{Emoji: "🚧", Code: "construction", Description: "Work in progress", Type: "chore"},
+	{Emoji: "💚", Code: "green_heart", Description: "Fix CI Build", Type: "ci"},
+	{Emoji: "⬇️", Code: "arrow_down", Description: "Downgrade dependencies", Type: "build"},
+	{Emoji: "⬆️", Code: "arrow_up", Description: "Upgrade dependencies", Type: "build"},
+	{Emoji: "📌", Code: "pushpin", Description: "Pin dependencies to specific versions", Type: "build"},
+	{Emoji: "👷", Code: "construction_worker", Description: "Add or update CI build system", Type: "ci"},
+	{Emoji: "📈", Code: "chart_with_upwards_trend", Description: "Add or update analytics or track code", Type: "feat"},
+	{Emoji: "♻️", Code: "recycle", Description: "Refactor code", Type: "refactor"},
+	{Emoji: "➕", Code: "heavy_plus_sign", Description: "Add a dependency", Type: "build"},
+	{Emoji: "➖", Code: "heavy_minus_sign", Description: "Remove a dependency", Type: "build"},
+	{Emoji: "🔧", Code: "wrench", Description: "Add or update configuration files", Type: "build"},
+	{Emoji: "🔨", Code: "hammer", Description: "Add or update development scripts", Type: "chore"},
+	{Emoji: "🌐", Code: "globe_with_meridians", Description: "Internationalization and localization", Type: "feat"},
+	{Emoji: "✏️", Code: "pencil2", Description: "Fix typos", Type: "fix"},
+	{Emoji: "💩", Code: "poop", Description: "Write bad code that needs to be improved", Type: "chore"},
+	{Emoji: "⏪️", Code: "rewind", Description: "Revert changes", Type: "revert"},
+	{Emoji: "🔀", Code: "twisted_rightwards_arrows", Description: "Merge branches", Type: "chore"},
+	{Emoji: "📦️", Code: "package", Description: "Add or update compiled files or packages", Type: "build"},
+	{Emoji: "👽️", Code: "alien", Description: "Update code due to external API changes", Type: "fix"},
+	{Emoji: "🚚", Code: "truck", Description: "Move or rename resources (e.g.: files, paths, routes)", Type: "refactor"},
+	{Emoji: "📄", Code: "page_facing_up", Description: "Add or update license", Type: "docs"},
+	{Emoji: "💥", Code: "boom", Description: "Introduce breaking changes", Type: "feat"},
+	{Emoji: "🍱", Code: "bento", Description: "Add or update assets", Type: "chore"},
+	{Emoji: "♿️", Code: "wheelchair", Description: "Improve accessibility", Type: "feat"},
+	{Emoji: "💡", Code: "bulb", Description: "Add or update comments in source code", Type: "docs"},
+	{Emoji: "🍻", Code: "beers", Description: "Write code drunkenly", Type: "chore"},
+	{Emoji: "💬", Code: "speech_balloon", Description: "Add or update text and literals", Type: "chore"},
+	{Emoji: "🗃️", Code: "card_file_box", Description: "Perform database related changes", Type: "chore"},
+	{Emoji: "🔊", Code: "loud_sound", Description: "Add or update logs", Type: "chore"},
+	{Emoji: "🔇", Code: "mute", Description: "Remove logs", Type: "chore"},
+	{Emoji: "👥", Code: "busts_in_silhouette", Description: "Add or update contributor(s)", Type: "docs"},
+	{Emoji: "🚸", Code: "children_crossing", Description: "Improve user experience / usability", Type: "feat"},
+	{Emoji: "🏗️", Code: "building_construction", Description: "Make architectural changes", Type: "refactor"},
+	{Emoji: "📱", Code: "iphone", Description: "Work on responsive design", Type: "feat"},
+	{Emoji: "🤡", Code: "clown_face", Description: "Mock things", Type: "test"},
+	{Emoji: "🥚", Code: "egg", Description: "Add or update an easter egg", Type: "feat"},
+	{Emoji: "🙈", Code: "see_no_evil", Description: "Add or update a .gitignore file", Type: "chore"},
+	{Emoji: "📸", Code: "camera_flash", Description: "Add or update snapshots", Type: "test"},
+	{Emoji: "⚗️", Code: "alembic", Description: "Perform experiments", Type: "chore"},
+	{Emoji: "🔍️", Code: "mag", Description: "Improve SEO", Type: "feat"},
+	{Emoji: "🏷️", Code: "label", Description: "Add or update types", Type: "refactor"},
+	{Emoji: "🌱", Code: "seedling", Description: "Add or update seed files", Type: "chore"},
+	{Emoji: "🚩", Code: "triangular_flag_on_post", Description: "Add, update, or remove feature flags", Type: "feat"},
+	{Emoji: "🥅", Code: "goal_net", Description: "Catch errors", Type: "fix"},
+	{Emoji: "💫", Code: "dizzy", Description: "Add or update animations and transitions", Type: "feat"},
+	{Emoji: "🗑️", Code: "wastebasket", Description: "Deprecate code that needs to be cleaned up", Type: "refactor"},
+	{Emoji: "🛂", Code: "passport_control", Description: "Work on code related to authorization, roles and permissions", Type: "feat"},
+	{Emoji: "🩹", Code: "adhesive_bandage", Description: "Simple fix for a non-critical issue", Type: "fix"},
+	{Emoji: "🧐", Code: "monocle_face", Description: "Data exploration/inspection", Type: "chore"},
+	{Emoji: "⚰️", Code: "coffin", Description: "Remove dead code", Type: "refactor"},
+	{Emoji: "🧪", Code: "test_tube", Description: "Add a failing test", Type: "test"},
+	{Emoji: "👔", Code: "necktie", Description: "Add or update business logic", Type: "feat"},
+	{Emoji: "🩺", Code: "stethoscope", Description: "Add or update healthcheck", Type: "feat"},
+	{Emoji: "🧱", Code: "bricks", Description: "Infrastructure related changes", Type: "build"},
+	{Emoji: "🧑‍💻", Code: "technologist", Description: "Improve developer experience", Type: "chore"},
+	{Emoji: "💸", Code: "money_with_wings", Description: "Add sponsorships or money related infrastructure", Type: "chore"},
+	{Emoji: "🧵", Code: "thread", Description: "Add or update code related to multithreading or concurrency", Type: "feat"},
+	{Emoji: "🦺", Code: "safety_vest", Description: "Add or update code related to validation", Type: "feat"},
+	{Emoji: "✈️", Code: "airplane", Description: "Improve offline support", Type: "feat"},
+}
+
+// Emoji placements in the header.
+const (
+	EmojiPlacementSubject = "subject" // type(scope): ✨ subject
+	EmojiPlacementPrefix  = "prefix"  // ✨ type(scope): subject
+	EmojiPlacementOnly    = "only"    // ✨ (scope): subject, gitmoji style
+)
+
+// Emoji output formats.
+const (
+	EmojiFormatUnicode   = "unicode"
+	EmojiFormatShortcode = "shortcode"
+)
+
+var shortcodePattern = regexp.MustCompile(`^:[a-z0-9_+-]+:$`)
+
+// LookupGitmoji finds a catalogue entry by emoji, ":code:" or "code".
+// Variation selectors are ignored when comparing emoji.
+func LookupGitmoji(s string) (Gitmoji, bool) {
+	code := strings.Trim(s, ":")
+	bare := stripVariation(s)
+	for _, g := range Gitmojis {
+		if g.Code == code || stripVariation(g.Emoji) == bare {
+			return g, true
+		}
+	}
+	return Gitmoji{}, false
+}
+
+// renderEmoji writes emoji in the requested format. Emoji that are not in
+// the catalogue, and unicode emoji in unicode format, are kept as given.
+func renderEmoji(emoji, format string) string {
+	isShortcode := shortcodePattern.MatchString(emoji)
+	if format == EmojiFormatShortcode && isShortcode || format != EmojiFormatShortcode && !isShortcode {
+		return emoji
+	}
+	g, ok := LookupGitmoji(emoji)
+	if !ok {
+		return emoji
+	}
+	if format == EmojiFormatShortcode {
+		return g.Shortcode()
+	}
+	return g.Emoji
+}
+
+// normalizeEmoji returns the catalogue form of a parsed emoji token.
+func normalizeEmoji(token string) string {
+	return renderEmoji(token, EmojiFormatUnicode)
+}
+
+// isEmoji reports whether token is a ":shortcode:" or made of emoji runes
+// only (symbols plus joiners, variation selectors and skin tones).
+func isEmoji(token string) bool {
+	if shortcodePattern.MatchString(token) {
+		return true
+	}
+	if token == "" {
+		return false
+	}
+	for _, r := range token {
+		switch {
+		case r == '\u200d', r == '\ufe0f', r >= 0x1f3fb && r <= 0x1f3ff:
+		case unicode.Is(unicode.So, r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// cutEmoji splits a leading emoji token followed by a space off s.
+func cutEmoji(s string) (emoji, rest string) {
+	token, rest, ok := strings.Cut(s, " ")
+	if !ok || !isEmoji(token) {
+		return "", s
+	}
+	return normalizeEmoji(token), rest
+}
+
+func stripVariation(s string) string {
+	return strings.ReplaceAll(s, "\ufe0f", "")
+}
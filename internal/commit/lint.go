@@ -0,0 +1,311 @@
+package commit
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Lint rule names.
+const (
+	RuleHeaderFormat      = "header-format"
+	RuleTypeEnum          = "type-enum"
+	RuleScopeEnum         = "scope-enum"
+	RuleSubjectEmpty      = "subject-empty"
+	RuleSubjectCase       = "subject-case"
+	RuleSubjectFullStop   = "subject-full-stop"
+	RuleHeaderMaxLength   = "header-max-length"
+	RuleBodyLeadingBlank  = "body-leading-blank"
+	RuleBodyMaxLineLength = "body-max-line-length"
+	RuleFooterToken       = "footer-token"
+)
+
+// LintRuleNames lists every lint rule in reporting order.
+var LintRuleNames = []string{
+	RuleHeaderFormat,
+	RuleTypeEnum,
+	RuleScopeEnum,
+	RuleSubjectEmpty,
+	RuleSubjectCase,
+	RuleSubjectFullStop,
+	RuleHeaderMaxLength,
+	RuleBodyLeadingBlank,
+	RuleBodyMaxLineLength,
+	RuleFooterToken,
+}
+
+// Level is the severity of a lint rule.
+type Level string
+
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+	LevelOff     Level = "off"
+)
+
+// Subject case styles accepted by LintRules.SubjectCase.
+const (
+	SubjectCaseLower    = "lower"
+	SubjectCaseSentence = "sentence"
+	SubjectCaseAny      = "any"
+)
+
+// LintRules configures Lint. Empty Types or Scopes disable the matching enum check.
+type LintRules struct {
+	Types             []string
+	Scopes            []string
+	SubjectCase       string
+	MaxHeaderLength   int
+	BodyMaxLineLength int
+	Levels            map[string]Level // per-rule override of the default level
+	Cleanup           Cleanup          // what git strips before the rules apply
+}
+
+// LintIssue is a single rule violation.
+type LintIssue struct {
+	Rule    string
+	Level   Level
+	Line    int // 1-based line in the cleaned message
+	Message string
+}
+
+// DefaultLintRules returns the rules used when nothing is configured.
+func DefaultLintRules() LintRules {
+	return LintRules{
+		Types:             DefaultTypes().Names(),
+		SubjectCase:       SubjectCaseLower,
+		MaxHeaderLength:   100,
+		BodyMaxLineLength: 100,
+	}
+}
+
+var defaultLevels = map[string]Level{
+	RuleBodyLeadingBlank: LevelWarning,
+}
+
+func (r LintRules) level(rule string) Level {
+	if lv, ok := r.Levels[rule]; ok {
+		return lv
+	}
+	if lv, ok := defaultLevels[rule]; ok {
+		return lv
+	}
+	return LevelError
+}
+
+// HasErrors reports whether any issue has error level.
+func HasErrors(issues []LintIssue) bool {
+	for _, i := range issues {
+		if i.Level == LevelError {
+			return true
+		}
+	}
+	return false
+}
+
+// scissorsText follows the comment character on git's scissors line.
+const scissorsText = " ------------------------ >8 ------------------------"
+
+// Cleanup modes of commit.cleanup.
+const (
+	CleanupStrip      = "strip"
+	CleanupWhitespace = "whitespace"
+	CleanupVerbatim   = "verbatim"
+	CleanupScissors   = "scissors"
+)
+
+// autoCommentChars are the characters core.commentChar=auto picks from, in
+// git's order.
+const autoCommentChars = "#;@!$%^&|:"
+
+// Cleanup is how git cleans a message up before committing it.
+type Cleanup struct {
+	// Mode is commit.cleanup; "" and "default" strip as for an edited message.
+	Mode string
+	// CommentChar is core.commentChar; "" means "#", and "auto" the
+	// character git picked for the message.
+	CommentChar string
+}
+
+// CleanMessage strips what git strips before committing with its default
+// settings: everything below the scissors line, "#" comment lines, trailing
+// whitespace and surrounding blank lines.
+func CleanMessage(message string) string {
+	return Cleanup{}.Clean(message)
+}
+
+// Clean strips what git strips from message before committing it.
+func (c Cleanup) Clean(message string) string {
+	if c.Mode == CleanupVerbatim {
+		return strings.TrimRight(message, "\n")
+	}
+	comment := c.commentChar(message)
+	stripComments := c.Mode != CleanupWhitespace && c.Mode != CleanupScissors
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == comment+scissorsText && c.Mode != CleanupWhitespace {
+			break
+		}
+		if stripComments && strings.HasPrefix(line, comment) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// commentChar resolves the comment character used in message. For "auto",
+// git marks its scissors line and instructions with the character it picked;
+// without them git picks the first candidate no line starts with.
+func (c Cleanup) commentChar(message string) string {
+	switch c.CommentChar {
+	case "":
+		return "#"
+	case "auto":
+	default:
+		return c.CommentChar
+	}
+	lines := strings.Split(message, "\n")
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		for _, ch := range autoCommentChars {
+			if line == string(ch)+scissorsText || strings.HasPrefix(line, string(ch)+" Please enter the commit message") {
+				return string(ch)
+			}
+		}
+	}
+	for _, ch := range autoCommentChars {
+		if !slices.ContainsFunc(lines, func(line string) bool { return strings.HasPrefix(line, string(ch)) }) {
+			return string(ch)
+		}
+	}
+	return "#"
+}
+
+var ignoredPattern = regexp.MustCompile(`^(Merge |Revert "|(fixup|squash|amend)! )`)
+
+// IsLintIgnored reports whether a header belongs to a git-generated message
+// (merges, reverts, autosquash markers) that is exempt from linting.
+func IsLintIgnored(header string) bool {
+	return ignoredPattern.MatchString(header)
+}
+
+// footerLikePattern also matches multi-word tokens so they can be reported.
+var footerLikePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*(?: [A-Za-z][A-Za-z0-9-]*){0,2}(: | #)`)
+
+// Lint checks message against rules and returns all violations found.
+func Lint(message string, rules LintRules) []LintIssue {
+	var issues []LintIssue
+	add := func(rule string, line int, format string, args ...any) {
+		lv := rules.level(rule)
+		if lv == LevelOff {
+			return
+		}
+		issues = append(issues, LintIssue{Rule: rule, Level: lv, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	cleaned := rules.Cleanup.Clean(message)
+	if cleaned == "" {
+		return nil // git aborts empty messages on its own
+	}
+	lines := strings.Split(cleaned, "\n")
+	header := lines[0]
+	if IsLintIgnored(header) {
+		return nil
+	}
+
+	if n := Width(header); rules.MaxHeaderLength > 0 && n > rules.MaxHeaderLength {
+		add(RuleHeaderMaxLength, 1, "header is %d columns, max is %d", n, rules.MaxHeaderLength)
+	}
+	h, err := ParseHeader(header)
+	if err != nil {
+		add(RuleHeaderFormat, 1, "header must match \"type(scope)!: subject\", got %q", header)
+	} else {
+		lintHeader(h, rules, add)
+	}
+
+	if len(lines) == 1 {
+		return issues
+	}
+	if lines[1] != "" {
+		add(RuleBodyLeadingBlank, 2, "body must be separated from the header by a blank line")
+	}
+
+	bodyEnd := len(lines)
+	if start, ok := footerStart(lines); ok {
+		bodyEnd = start
+		lintFooter(lines, start, add)
+	}
+	if rules.BodyMaxLineLength > 0 {
+		for i := 1; i < bodyEnd; i++ {
+			if n := Width(lines[i]); n > rules.BodyMaxLineLength {
+				add(RuleBodyMaxLineLength, i+1, "body line is %d columns, max is %d", n, rules.BodyMaxLineLength)
+			}
+		}
+	}
+	return issues
+}
+
+func lintHeader(h Header, rules LintRules, add func(string, int, string, ...any)) {
+	if len(rules.Types) > 0 && !slices.Contains(rules.Types, h.Type) {
+		add(RuleTypeEnum, 1, "type %q is not one of: %s", h.Type, strings.Join(rules.Types, ", "))
+	}
+	if h.Scope != "" && len(rules.Scopes) > 0 && !slices.Contains(rules.Scopes, h.Scope) {
+		add(RuleScopeEnum, 1, "scope %q is not one of: %s", h.Scope, strings.Join(rules.Scopes, ", "))
+	}
+	subject := strings.TrimSpace(h.Subject)
+	if subject == "" {
+		add(RuleSubjectEmpty, 1, "subject must not be empty")
+		return
+	}
+	first, _ := utf8.DecodeRuneInString(subject)
+	switch rules.SubjectCase {
+	case SubjectCaseLower:
+		if unicode.IsUpper(first) {
+			add(RuleSubjectCase, 1, "subject must start with a lowercase letter")
+		}
+	case SubjectCaseSentence:
+		if unicode.IsLower(first) {
+			add(RuleSubjectCase, 1, "subject must start with an uppercase letter")
+		}
+	}
+	if strings.HasSuffix(subject, ".") {
+		add(RuleSubjectFullStop, 1, "subject must not end with a full stop")
+	}
+}
+
+// footerStart returns the index of the first line of the footer paragraph.
+// The last paragraph is a footer when it is made up of lines that look like
+// trailers and their continuations.
+func footerStart(lines []string) (int, bool) {
+	start := len(lines) - 1
+	for start > 1 && lines[start-1] != "" {
+		start--
+	}
+	if start < 2 || lines[start-1] != "" {
+		return 0, false
+	}
+	if !isTrailerParagraph(lines[start:], footerLikePattern) {
+		return 0, false
+	}
+	return start, true
+}
+
+func lintFooter(lines []string, start int, add func(string, int, string, ...any)) {
+	for i := start; i < len(lines); i++ {
+		line := lines[i]
+		if line == "" || line[0] == ' ' || line[0] == '\t' {
+			continue // continuation of the previous value
+		}
+		if footerPattern.MatchString(line) {
+			continue
+		}
+		if footerLikePattern.MatchString(line) {
+			add(RuleFooterToken, i+1, "footer token in %q must be a single word (use - for spaces) or BREAKING CHANGE", line)
+		}
+	}
+}
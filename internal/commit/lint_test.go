@@ -0,0 +1,119 @@
+package commit
+
+import "testing"
+
+func lintRules(issues []LintIssue) []string {
+	var rules []string
+	for _, i := range issues {
+		rules = append(rules, i.Rule)
+	}
+	return rules
+}
+
+func TestLint_ValidMessage(t *testing.T) {
+	msg := "feat(core): add retry\n\nRetry failed webhooks.\n\nRefs: #12\nBREAKING CHANGE: the retry\n  option is now required"
+	rules := DefaultLintRules()
+	rules.Scopes = []string{"core"}
+	if issues := Lint(msg, rules); len(issues) != 0 {
+		t.Fatalf("unexpected issues: %v", issues)
+	}
+}
+
+func TestLint_HeaderRules(t *testing.T) {
+	rules := DefaultLintRules()
+	rules.Scopes = []string{"core"}
+	rules.MaxHeaderLength = 20
+
+	tests := []struct {
+		msg  string
+		want []string
+	}{
+		{"update stuff", []string{RuleHeaderFormat}},
+		{"feature: add x", []string{RuleTypeEnum}},
+		{"fix(cli): add x", []string{RuleScopeEnum}},
+		{"fix: Add x", []string{RuleSubjectCase}},
+		{"fix: add x.", []string{RuleSubjectFullStop}},
+		{"fix:  ", []string{RuleHeaderFormat}},
+		{"fix: add a much longer subject", []string{RuleHeaderMaxLength}},
+		{"fix: 日本語の件名です", []string{RuleHeaderMaxLength}}, // 13 characters, 21 columns
+	}
+	for _, tt := range tests {
+		got := lintRules(Lint(tt.msg, rules))
+		if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+			t.Errorf("Lint(%q) rules = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestLint_BodyAndFooterRules(t *testing.T) {
+	rules := DefaultLintRules()
+	rules.BodyMaxLineLength = 10
+
+	issues := Lint("fix: a\nbody right away that is long\n\nBreaking change: x", rules)
+	got := lintRules(issues)
+	want := []string{RuleBodyLeadingBlank, RuleFooterToken, RuleBodyMaxLineLength}
+	if len(got) != len(want) {
+		t.Fatalf("rules = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("rules = %v, want %v", got, want)
+		}
+	}
+	if issues[0].Level != LevelWarning || issues[0].Line != 2 {
+		t.Fatalf("unexpected leading-blank issue: %+v", issues[0])
+	}
+	if !HasErrors(issues) {
+		t.Fatal("expected errors")
+	}
+}
+
+func TestLint_LevelsOverride(t *testing.T) {
+	rules := DefaultLintRules()
+	rules.Levels = map[string]Level{RuleSubjectCase: LevelOff, RuleTypeEnum: LevelWarning}
+	issues := Lint("wip: Add x", rules)
+	if len(issues) != 1 || issues[0].Rule != RuleTypeEnum || HasErrors(issues) {
+		t.Fatalf("unexpected issues: %v", issues)
+	}
+}
+
+func TestLint_IgnoresGeneratedAndComments(t *testing.T) {
+	if issues := Lint("Merge branch 'main' into topic", DefaultLintRules()); issues != nil {
+		t.Fatalf("merge commit should be ignored: %v", issues)
+	}
+	msg := "fix: a\n# Please enter the commit message\n# ------------------------ >8 ------------------------\ndiff --git a/x b/x\n"
+	if issues := Lint(msg, DefaultLintRules()); len(issues) != 0 {
+		t.Fatalf("comments and scissors should be stripped: %v", issues)
+	}
+}
+
+func TestCleanup_Clean(t *testing.T) {
+	tests := []struct {
+		cleanup Cleanup
+		msg     string
+		want    string
+	}{
+		{Cleanup{}, "fix: a\n\n#123 fixes\n", "fix: a"},
+		{Cleanup{CommentChar: ";"}, "fix: a\n\n#123 fixes\n; comment\n", "fix: a\n\n#123 fixes"},
+		{Cleanup{CommentChar: ";"}, "fix: a\n; ------------------------ >8 ------------------------\ndiff", "fix: a"},
+		{Cleanup{CommentChar: "auto"}, "fix: a\n\n#123 fixes\n; Please enter the commit message for your changes.\n", "fix: a\n\n#123 fixes"},
+		{Cleanup{CommentChar: "auto"}, "fix: a\n\n#123 fixes\n", "fix: a\n\n#123 fixes"},
+		{Cleanup{Mode: CleanupWhitespace}, "fix: a\n# kept  \n\n", "fix: a\n# kept"},
+		{Cleanup{Mode: CleanupScissors}, "fix: a\n# kept\n# ------------------------ >8 ------------------------\ndiff", "fix: a\n# kept"},
+		{Cleanup{Mode: CleanupVerbatim}, "fix: a  \n# kept\n", "fix: a  \n# kept"},
+	}
+	for _, tt := range tests {
+		if got := tt.cleanup.Clean(tt.msg); got != tt.want {
+			t.Errorf("%+v.Clean(%q) = %q, want %q", tt.cleanup, tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestLint_TrailerLikeBodyParagraph(t *testing.T) {
+	rules := DefaultLintRules()
+	rules.BodyMaxLineLength = 30
+	issues := Lint("docs: x\n\nSee also: the API reference, which explains it.\nIt is long.", rules)
+	if got := lintRules(issues); len(got) != 1 || got[0] != RuleBodyMaxLineLength {
+		t.Fatalf("expected the paragraph to be linted as body, got %v", issues)
+	}
+}
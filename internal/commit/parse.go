@@ -0,0 +1,253 @@
+package commit
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ErrNotConventional is returned when a header does not follow the
+// `type(scope)!: subject` layout.
+var ErrNotConventional = errors.New("header is not in Conventional Commits format")
+
+// Header is the parsed first line of a Conventional Commits message.
+type Header struct {
+	Emoji    string // gitmoji before the type or subject, in unicode form
+	Type     string
+	Scope    string
+	Breaking bool
+	Subject  string
+}
+
+// Footer is a git trailer in the footer of a Conventional Commits message.
+type Footer struct {
+	Token     string // e.g. "Refs" or "BREAKING CHANGE"
+	Separator string // ": " or " #"
+	Value     string // may span several lines
+}
+
+// IsBreaking reports whether the footer announces a breaking change.
+func (f Footer) IsBreaking() bool {
+	return f.Token == "BREAKING CHANGE" || f.Token == "BREAKING-CHANGE"
+}
+
+var (
+	headerPattern = regexp.MustCompile(`^(?:(:[a-z0-9_+-]+:|[^\x00-\x7F]+) )?([A-Za-z][A-Za-z0-9_-]*)(?:\(([^()\r\n]*)\))?(!)?: (.*)$`)
+	// emojiHeaderPattern matches gitmoji-style headers without a type:
+	// "✨ (scope)!: subject" or "✨ subject".
+	emojiHeaderPattern = regexp.MustCompile(`^(:[a-z0-9_+-]+:|[^\x00-\x7F]+) (?:(?:\(([^()\r\n]*)\))?(!)?: )?(.+)$`)
+	footerPattern      = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[A-Za-z][A-Za-z0-9-]*)(: | #)(.*)$`)
+)
+
+// ParseHeader splits a header line into its Conventional Commits parts.
+// A gitmoji before the type or at the start of the subject is moved to
+// Emoji. Emoji-only headers ("✨ (scope): subject") take their type from the
+// gitmoji catalogue.
+func ParseHeader(line string) (Header, error) {
+	h, _, err := parseHeader(line)
+	return h, err
+}
+
+// parseHeader also returns the offset in line where the "!" is, or would be
+// inserted.
+func parseHeader(line string) (Header, int, error) {
+	if m := headerPattern.FindStringSubmatchIndex(line); m != nil && (m[2] < 0 || isEmoji(line[m[2]:m[3]])) {
+		h := Header{
+			Type:     line[m[4]:m[5]],
+			Breaking: m[8] >= 0,
+			Subject:  line[m[10]:m[11]],
+		}
+		if m[6] >= 0 {
+			h.Scope = line[m[6]:m[7]]
+		}
+		if m[2] >= 0 {
+			h.Emoji = normalizeEmoji(line[m[2]:m[3]])
+		} else {
+			h.Emoji, h.Subject = cutEmoji(h.Subject)
+		}
+		return h, bangOffset(m[8], m[10]), nil
+	}
+	m := emojiHeaderPattern.FindStringSubmatchIndex(line)
+	if m == nil || !isEmoji(line[m[2]:m[3]]) {
+		return Header{}, 0, ErrNotConventional
+	}
+	g, ok := LookupGitmoji(line[m[2]:m[3]])
+	if !ok {
+		return Header{}, 0, ErrNotConventional
+	}
+	h := Header{
+		Emoji:    g.Emoji,
+		Type:     g.Type,
+		Breaking: m[6] >= 0,
+		Subject:  line[m[8]:m[9]],
+	}
+	if m[4] >= 0 {
+		h.Scope = line[m[4]:m[5]]
+	}
+	if m[8] == m[3]+len(" ") {
+		return h, -1, nil // no ": " to put a "!" before
+	}
+	return h, bangOffset(m[6], m[8]), nil
+}
+
+// bangOffset returns where the "!" starts given the start of the "!" group
+// (-1 when absent) and of the subject, which follows ": ".
+func bangOffset(bang, subject int) int {
+	if bang >= 0 {
+		return bang
+	}
+	return subject - len(": ")
+}
+
+// Parse parses a full Conventional Commits message. The footer is the
+// trailing run of paragraphs made up of trailers only, as git's trailer
+// detection has it (see isTrailerParagraph). Breaking reflects the "!" in
+// the header only, so formatting the result does not add one for a
+// BREAKING CHANGE footer; use IsBreaking to also honour the footer.
+//
+// Parse does not keep how the message was written: Format(Parse(msg))
+// places and spells a gitmoji as its FormatOptions say and tidies the body
+// with FormatBody, so it gives msg back only when msg is in that form.
+func Parse(message string) (*ConventionalCommit, error) {
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+	h, err := ParseHeader(lines[0])
+	if err != nil {
+		return nil, err
+	}
+	c := &ConventionalCommit{
+		Emoji:    h.Emoji,
+		Type:     h.Type,
+		Scope:    h.Scope,
+		Breaking: h.Breaking,
+		Subject:  h.Subject,
+	}
+
+	rest := lines[1:]
+	start := footerParagraph(rest)
+	c.Body = strings.Trim(strings.Join(rest[:start], "\n"), "\n")
+	c.Footers, _ = ParseFooters(strings.Join(rest[start:], "\n"))
+	return c, nil
+}
+
+// ParseFooters parses a trailer block. Lines that do not start a trailer
+// continue the previous value; ok is false when text does not start with a
+// trailer, in which case no footers are returned.
+func ParseFooters(text string) (footers []Footer, ok bool) {
+	text = strings.Trim(text, "\n")
+	if text == "" {
+		return nil, true
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if m := footerPattern.FindStringSubmatch(line); m != nil {
+			footers = append(footers, Footer{Token: m[1], Separator: m[2], Value: m[3]})
+			continue
+		}
+		if len(footers) == 0 {
+			return nil, false
+		}
+		footers[len(footers)-1].Value += "\n" + line
+	}
+	for i := range footers {
+		footers[i].Value = strings.TrimRight(footers[i].Value, "\n")
+	}
+	return footers, true
+}
+
+// MarkBreaking adds or removes the "!" of a Conventional Commits header,
+// keeping any gitmoji where it is. Headers that do not parse are returned
+// unchanged.
+func MarkBreaking(header string, breaking bool) string {
+	h, at, err := parseHeader(header)
+	if err != nil || h.Breaking == breaking {
+		return header
+	}
+	if !breaking {
+		return header[:at] + header[at+1:]
+	}
+	if at < 0 {
+		// "✨ subject" has no ": "; give it one.
+		emoji, rest, _ := strings.Cut(header, " ")
+		return emoji + " !: " + rest
+	}
+	return header[:at] + "!" + header[at:]
+}
+
+// CutEmoji splits the gitmoji off a Conventional Commits header. Emoji-only
+// headers get the type implied by their gitmoji. Headers without an emoji,
+// or that do not parse, are returned unchanged with an empty emoji.
+func CutEmoji(header string) (emoji, rest string) {
+	h, err := ParseHeader(header)
+	if err != nil || h.Emoji == "" {
+		return "", header
+	}
+	c := &ConventionalCommit{Type: h.Type, Scope: h.Scope, Breaking: h.Breaking, Subject: h.Subject}
+	return h.Emoji, Format(c, FormatOptions{})
+}
+
+// footerParagraph returns the index in lines (the message below the header)
+// where the footer starts, or len(lines) when there is none.
+func footerParagraph(lines []string) int {
+	start := len(lines)
+	for i := len(lines) - 1; i >= 1; i-- {
+		if lines[i-1] != "" || lines[i] == "" {
+			continue
+		}
+		end := i
+		for end < len(lines) && lines[end] != "" {
+			end++
+		}
+		if !isTrailerParagraph(lines[i:end], footerPattern) {
+			break
+		}
+		start = i
+	}
+	return start
+}
+
+// isTrailerParagraph reports whether para starts with a trailer matching
+// pattern and every other line is a trailer too or continues the one before
+// it. Like git, continuation lines are indented; the note of a BREAKING
+// CHANGE footer may also go on unindented, as Conventional Commits allows.
+func isTrailerParagraph(para []string, pattern *regexp.Regexp) bool {
+	if len(para) == 0 || !pattern.MatchString(para[0]) {
+		return false
+	}
+	breaking := false
+	for _, line := range para {
+		switch {
+		case pattern.MatchString(line):
+			m := footerPattern.FindStringSubmatch(line)
+			breaking = m != nil && Footer{Token: m[1]}.IsBreaking()
+		case strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || breaking:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// IsBreaking reports whether the commit is breaking, via "!" or a
+// BREAKING CHANGE footer.
+func (c *ConventionalCommit) IsBreaking() bool {
+	return c.Breaking || slices.ContainsFunc(c.Footers, Footer.IsBreaking)
+}
+
+// BreakingNote returns the value of the first BREAKING CHANGE footer.
+func (c *ConventionalCommit) BreakingNote() string {
+	for _, f := range c.Footers {
+		if f.IsBreaking() {
+			return strings.TrimSpace(f.Value)
+		}
+	}
+	return ""
+}
+
+// FormatFooters renders footers as a trailer block.
+func FormatFooters(footers []Footer) string {
+	lines := make([]string, len(footers))
+	for i, f := range footers {
+		lines[i] = f.Token + f.Separator + f.Value
+	}
+	return strings.Join(lines, "\n")
+}
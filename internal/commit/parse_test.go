@@ -0,0 +1,218 @@
+package commit
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse_Full(t *testing.T) {
+	msg := "feat(api)!: drop v1 endpoints\n\nFirst paragraph.\n\nSecond paragraph\nwith two lines.\n\nBREAKING CHANGE: clients must use /v2\n  and re-authenticate.\nRefs #42\nReviewed-by: Jane Doe <jane@example.com>\n"
+	got, err := Parse(msg)
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	want := &ConventionalCommit{
+		Type:     "feat",
+		Scope:    "api",
+		Breaking: true,
+		Subject:  "drop v1 endpoints",
+		Body:     "First paragraph.\n\nSecond paragraph\nwith two lines.",
+		Footers: []Footer{
+			{Token: "BREAKING CHANGE", Separator: ": ", Value: "clients must use /v2\n  and re-authenticate."},
+			{Token: "Refs", Separator: " #", Value: "42"},
+			{Token: "Reviewed-by", Separator: ": ", Value: "Jane Doe <jane@example.com>"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected result:\n got %#v\nwant %#v", got, want)
+	}
+	if got.BreakingNote() != "clients must use /v2\n  and re-authenticate." {
+		t.Fatalf("unexpected breaking note: %q", got.BreakingNote())
+	}
+}
+
+func TestParse_BreakingFooterOnly(t *testing.T) {
+	got, err := Parse("fix: a\n\nBREAKING-CHANGE: removed flag")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if got.Breaking || !got.IsBreaking() || got.Body != "" {
+		t.Fatalf("unexpected result: %#v", got)
+	}
+}
+
+func TestParse_BodyNotFooter(t *testing.T) {
+	got, err := Parse("docs: explain\n\nNote: this is prose.\n\nAnd more prose.")
+	if err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if len(got.Footers) != 0 || got.Body != "Note: this is prose.\n\nAnd more prose." {
+		t.Fatalf("unexpected result: %#v", got)
+	}
+}
+
+func TestParse_TrailerLikeBodyParagraph(t *testing.T) {
+	tests := []struct {
+		msg, body string
+		footers   int
+	}{
+		{"feat: x\n\nNote: this changes the API.\nIt is documented below.", "Note: this changes the API.\nIt is documented below.", 0},
+		{"feat: x\n\nBody.\n\nRefs: #1\nthen prose", "Body.\n\nRefs: #1\nthen prose", 0},
+		{"feat: x\n\nBody.\n\nReviewed-by: A <a@example.com>\n  and B <b@example.com>\nRefs: #1", "Body.", 2},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.msg)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.msg, err)
+		}
+		if got.Body != tt.body || len(got.Footers) != tt.footers {
+			t.Errorf("Parse(%q) = body %q, %d footers; want %q, %d", tt.msg, got.Body, len(got.Footers), tt.body, tt.footers)
+		}
+	}
+}
+
+func TestParse_NotConventional(t *testing.T) {
+	if _, err := Parse("Update README"); !errors.Is(err, ErrNotConventional) {
+		t.Fatalf("expected ErrNotConventional, got %v", err)
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	messages := []string{
+		"chore: bump deps",
+		"my-type(ui)!: rework layout",
+		"fix(core): handle nil\n\nThe config could be nil.",
+		"feat: add x\n\nBody.\n\nRefs #1\nBREAKING CHANGE: a\nmulti-line note",
+		"perf: cache\n\nCo-authored-by: A <a@example.com>",
+	}
+	for _, msg := range messages {
+		c, err := Parse(msg)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", msg, err)
+		}
+		if got := Format(c, FormatOptions{}); got != msg {
+			t.Errorf("round trip mismatch:\n got %q\nwant %q", got, msg)
+		}
+		again, err := Parse(Format(c, FormatOptions{}))
+		if err != nil || !reflect.DeepEqual(again, c) {
+			t.Errorf("Parse(Format(c)) != c for %q: %#v", msg, again)
+		}
+	}
+}
+
+func TestParse_FormatNormalizes(t *testing.T) {
+	prefix := FormatOptions{EmojiPlacement: EmojiPlacementPrefix}
+	shortcode := FormatOptions{EmojiPlacement: EmojiPlacementPrefix, EmojiFormat: EmojiFormatShortcode}
+	tests := []struct {
+		msg  string
+		opts FormatOptions
+		want string
+	}{
+		{"✨ feat: x", FormatOptions{}, "feat: ✨ x"},
+		{"✨ feat: x", prefix, "✨ feat: x"},
+		{":sparkles: feat: x", prefix, "✨ feat: x"},
+		{":sparkles: feat: x", shortcode, ":sparkles: feat: x"},
+		{"fix: a\n\nline one  \n\n\n\nline two", FormatOptions{}, "fix: a\n\nline one\n\nline two"},
+		{"fix: a\n\n* one\n* two", FormatOptions{NormalizeBullets: true}, "fix: a\n\n- one\n- two"},
+	}
+	for _, tt := range tests {
+		c, err := Parse(tt.msg)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", tt.msg, err)
+		}
+		if got := Format(c, tt.opts); got != tt.want {
+			t.Errorf("Format(Parse(%q), %+v) = %q, want %q", tt.msg, tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestParseFooters(t *testing.T) {
+	got, ok := ParseFooters("Refs: #1\nBREAKING CHANGE: a\nb")
+	if !ok || len(got) != 2 || got[1].Value != "a\nb" {
+		t.Fatalf("unexpected footers: %#v %v", got, ok)
+	}
+	if _, ok := ParseFooters("free text\nRefs: #1"); ok {
+		t.Fatal("expected ok=false for text not starting with a trailer")
+	}
+}
+
+func TestMarkBreaking(t *testing.T) {
+	tests := []struct {
+		header   string
+		breaking bool
+		want     string
+	}{
+		{"feat(api): drop v1", true, "feat(api)!: drop v1"},
+		{"feat(api)!: drop v1", false, "feat(api): drop v1"},
+		{"fix!: x", true, "fix!: x"},
+		{"Merge branch 'x'", true, "Merge branch 'x'"},
+	}
+	for _, tt := range tests {
+		if got := MarkBreaking(tt.header, tt.breaking); got != tt.want {
+			t.Errorf("MarkBreaking(%q, %v) = %q, want %q", tt.header, tt.breaking, got, tt.want)
+		}
+	}
+}
+
+func TestParseHeader_Gitmoji(t *testing.T) {
+	tests := []struct {
+		line string
+		want Header
+	}{
+		{"✨ feat(api): add search", Header{Emoji: "✨", Type: "feat", Scope: "api", Subject: "add search"}},
+		{":sparkles: feat(api)!: add search", Header{Emoji: "✨", Type: "feat", Scope: "api", Breaking: true, Subject: "add search"}},
+		{"fix(auth): :lock: rotate keys", Header{Emoji: "🔒️", Type: "fix", Scope: "auth", Subject: "rotate keys"}},
+		{"fix: 🐛 handle nil", Header{Emoji: "🐛", Type: "fix", Subject: "handle nil"}},
+		{"🔒️ (auth): rotate keys", Header{Emoji: "🔒️", Type: "fix", Scope: "auth", Subject: "rotate keys"}},
+		{":memo: explain flags", Header{Emoji: "📝", Type: "docs", Subject: "explain flags"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseHeader(tt.line)
+		if err != nil {
+			t.Errorf("ParseHeader(%q) error: %v", tt.line, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseHeader(%q) = %#v, want %#v", tt.line, got, tt.want)
+		}
+	}
+	for _, line := range []string{":nope: do things", "修正 feat: x", "Update README"} {
+		if _, err := ParseHeader(line); !errors.Is(err, ErrNotConventional) {
+			t.Errorf("ParseHeader(%q) = %v, want ErrNotConventional", line, err)
+		}
+	}
+}
+
+func TestMarkBreaking_Gitmoji(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"✨ feat(api): add search", "✨ feat(api)!: add search"},
+		{"feat: ✨ add search", "feat!: ✨ add search"},
+		{"✨ (api): add search", "✨ (api)!: add search"},
+		{"✨ add search", "✨ !: add search"},
+	}
+	for _, tt := range tests {
+		got := MarkBreaking(tt.in, true)
+		if got != tt.want {
+			t.Errorf("MarkBreaking(%q, true) = %q, want %q", tt.in, got, tt.want)
+		}
+		if back := MarkBreaking(got, false); tt.in != "✨ add search" && back != tt.in {
+			t.Errorf("MarkBreaking(%q, false) = %q, want %q", got, back, tt.in)
+		}
+	}
+}
+
+func TestCutEmoji(t *testing.T) {
+	tests := []struct{ in, emoji, rest string }{
+		{"fix(auth)!: :lock: rotate keys", "🔒️", "fix(auth)!: rotate keys"},
+		{"📝 explain flags", "📝", "docs: explain flags"},
+		{"feat: add search", "", "feat: add search"},
+		{"add search", "", "add search"},
+	}
+	for _, tt := range tests {
+		emoji, rest := CutEmoji(tt.in)
+		if emoji != tt.emoji || rest != tt.rest {
+			t.Errorf("CutEmoji(%q) = %q, %q, want %q, %q", tt.in, emoji, rest, tt.emoji, tt.rest)
+		}
+	}
+}
@@ -1,43 +1,90 @@
 package commit
 
+import "strings"
+
 // ConventionalCommit represents a Conventional Commits message.
 type ConventionalCommit struct {
+	Emoji    string // gitmoji for the header; without it UseEmoji falls back to the type emoji
 	Type     string
 	Scope    string
 	Breaking bool
 	Subject  string
 	Body     string
-	Footer   string
+	Footer   string   // free-form footer text, written before Footers
+	Footers  []Footer // structured trailers, filled by Parse
+}
+
+// AutoType is the pseudo type that lets the AI pick the type and header.
+const AutoType = "auto"
+
+// AutoTypeDescription describes AutoType in type lists.
+const AutoTypeDescription = "AI selects type and header"
+
+// Version bumps a commit type can trigger.
+const (
+	BumpNone  = "none"
+	BumpPatch = "patch"
+	BumpMinor = "minor"
+	BumpMajor = "major"
+)
+
+// Type describes one commit type.
+type Type struct {
+	Name        string
+	Description string
+	Emoji       string // gitmoji-style emoji used when emoji output is on
+	Bump        string // BumpNone, BumpPatch, BumpMinor or BumpMajor
+}
+
+// Types is an ordered list of commit types.
+type Types []Type
+
+// DefaultTypes returns the built-in commit types in display order.
+func DefaultTypes() Types {
+	return Types{
+		{Name: "feat", Description: "A new feature", Emoji: "✨", Bump: BumpMinor},
+		{Name: "fix", Description: "A bug fix", Emoji: "🐛", Bump: BumpPatch},
+		{Name: "docs", Description: "Documentation only changes", Emoji: "📝", Bump: BumpNone},
+		{Name: "style", Description: "Changes that do not affect the meaning of the code", Emoji: "💄", Bump: BumpNone},
+		{Name: "refactor", Description: "A code change that neither fixes a bug nor adds a feature", Emoji: "♻️", Bump: BumpNone},
+		{Name: "perf", Description: "A code change that improves performance", Emoji: "⚡️", Bump: BumpPatch},
+		{Name: "test", Description: "Adding missing tests or correcting existing tests", Emoji: "✅", Bump: BumpNone},
+		{Name: "build", Description: "Changes that affect the build system or external dependencies", Emoji: "🔧", Bump: BumpNone},
+		{Name: "ci", Description: "Changes to CI configuration files and scripts", Emoji: "👷", Bump: BumpNone},
+		{Name: "chore", Description: "Other changes that don't modify src or test files", Emoji: "🔨", Bump: BumpNone},
+		{Name: "revert", Description: "Reverts a previous commit", Emoji: "⏪", Bump: BumpNone},
+	}
+}
+
+// Names returns the type names in order.
+func (ts Types) Names() []string {
+	names := make([]string, len(ts))
+	for i, t := range ts {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Lookup returns the type called name.
+func (ts Types) Lookup(name string) (Type, bool) {
+	for _, t := range ts {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Type{}, false
 }
 
-// CommitTypes is the ordered list of supported commit types.
-var CommitTypes = []string{
-	"auto",
-	"feat",
-	"fix",
-	"docs",
-	"style",
-	"refactor",
-	"perf",
-	"test",
-	"build",
-	"ci",
-	"chore",
-	"revert",
+// orDefault returns ts, or the built-in types when ts is empty.
+func (ts Types) orDefault() Types {
+	if len(ts) == 0 {
+		return DefaultTypes()
+	}
+	return ts
 }
 
-// CommitTypeDescriptions maps each type to a short description.
-var CommitTypeDescriptions = map[string]string{
-	"auto":     "AI selects type and header",
-	"feat":     "A new feature",
-	"fix":      "A bug fix",
-	"docs":     "Documentation only changes",
-	"style":    "Changes that do not affect the meaning of the code",
-	"refactor": "A code change that neither fixes a bug nor adds a feature",
-	"perf":     "A code change that improves performance",
-	"test":     "Adding missing tests or correcting existing tests",
-	"build":    "Changes that affect the build system or external dependencies",
-	"ci":       "Changes to CI configuration files and scripts",
-	"chore":    "Other changes that don't modify src or test files",
-	"revert":   "Reverts a previous commit",
+// String lists the type names separated by commas, as used in prompts and
+// error messages.
+func (ts Types) String() string {
+	return strings.Join(ts.Names(), ", ")
 }
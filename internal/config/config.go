@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hayatosc/git-cx/internal/commit"
 	"github.com/hayatosc/git-cx/internal/git"
 )
 
@@ -17,9 +18,15 @@ type Config struct {
 	Model      string
 	Candidates int
 	Timeout    int
-	Command    string // for custom provider: supports {prompt} placeholder
+	Command    string   // for custom provider: supports {prompt} placeholder
+	CoAuthors  []string // "Name <email>" suggested for Co-authored-by
+	Types      commit.Types
+	TypeOrder  []string // cx.types.order as configured
 	API        APIConfig
 	Commit     CommitConfig
+	Lint       LintConfig
+	Branch     BranchConfig
+	UI         UIConfig
 }
 
 // APIConfig holds API provider settings.
@@ -31,13 +38,47 @@ type APIConfig struct {
 // CommitConfig holds commit message formatting settings.
 type CommitConfig struct {
 	UseEmoji         bool
+	Gitmoji          bool   // pick from the full gitmoji catalogue
+	EmojiPlacement   string // subject, prefix or only
+	EmojiFormat      string // unicode or shortcode
 	MaxSubjectLength int
+	BodyWrap         int  // 0 disables wrapping
+	NormalizeBullets bool // rewrite "*", "+" and "•" bullets as "-"
+	Signoff          bool // pass --signoff to git commit
+	GPGSign          bool // pass -S to git commit
 	Scopes           []string
+	Trailers         []string // default trailers, "Token" or "Token: value"
+}
+
+// LintConfig holds commit message lint settings.
+type LintConfig struct {
+	Types             []string // allowed types; defaults to the built-in types
+	SubjectCase       string   // lower, sentence or any
+	MaxHeaderLength   int
+	BodyMaxLineLength int
+	Disable           []string // rule names turned off
+	Warn              []string // rule names reported as warnings
+}
+
+// BranchConfig holds branch name generation settings.
+type BranchConfig struct {
+	Pattern string // supports {type}, {slug} and {ticket} placeholders
+}
+
+// UIConfig holds TUI appearance and key binding settings.
+type UIConfig struct {
+	Theme  string              // dark, light, high-contrast or custom
+	Colors map[string]string   // colour per style, on top of the theme
+	Keymap string              // default or vim
+	Keys   map[string][]string // keys per action, replacing the keymap's
 }
 
 // Load reads config from git config, falling back to defaults.
 func Load(ctx context.Context, runner git.Runner) (*Config, error) {
-	cfg := loadBase(ctx, runner)
+	cfg, err := loadBase(ctx, runner)
+	if err != nil {
+		return nil, err
+	}
 	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
@@ -47,7 +88,10 @@ func Load(ctx context.Context, runner git.Runner) (*Config, error) {
 // LoadWithFile reads config from git config and, if path is non-empty,
 // merges the gitconfig-format file at that path on top.
 func LoadWithFile(ctx context.Context, runner git.Runner, path string) (*Config, error) {
-	cfg := loadBase(ctx, runner)
+	cfg, err := loadBase(ctx, runner)
+	if err != nil {
+		return nil, err
+	}
 	if path != "" {
 		if err := ApplyGitConfigFile(ctx, runner, cfg, path); err != nil {
 			return nil, fmt.Errorf("failed to load config file %q: %w", path, err)
@@ -56,7 +100,10 @@ func LoadWithFile(ctx context.Context, runner git.Runner, path string) (*Config,
 	return cfg, cfg.Validate()
 }
 
-func loadBase(ctx context.Context, runner git.Runner) *Config {
+// loadBase reads the cx.* settings from git config. Values that are not
+// kept as typed (such as the cx.types.* booleans) are checked here; the rest
+// are left to Validate.
+func loadBase(ctx context.Context, runner git.Runner) (*Config, error) {
 	cfg := DefaultConfig()
 
 	if v := runner.ConfigGet(ctx, "cx.provider"); v != "" {
@@ -81,6 +128,9 @@ func loadBase(ctx context.Context, runner git.Runner) *Config {
 	if v := runner.ConfigGet(ctx, "cx.apiBaseUrl"); v != "" {
 		cfg.API.BaseURL = v
 	}
+	if coauthors := runner.ConfigGetAll(ctx, "cx.coauthors"); len(coauthors) > 0 {
+		cfg.CoAuthors = coauthors
+	}
 	if v := strings.TrimSpace(os.Getenv("OPENAI_API_KEY")); v != "" {
 		cfg.API.Key = v
 	}
@@ -91,16 +141,92 @@ func loadBase(ctx context.Context, runner git.Runner) *Config {
 			cfg.Commit.UseEmoji = b
 		}
 	}
+	if v := runner.ConfigGet(ctx, "cx.commit.gitmoji"); v != "" {
+		if b, ok := parseGitBool(v); ok {
+			cfg.Commit.Gitmoji = b
+		}
+	}
+	if v := runner.ConfigGet(ctx, "cx.commit.emojiPlacement"); v != "" {
+		cfg.Commit.EmojiPlacement = v
+	}
+	if v := runner.ConfigGet(ctx, "cx.commit.emojiFormat"); v != "" {
+		cfg.Commit.EmojiFormat = v
+	}
 	if v := runner.ConfigGet(ctx, "cx.commit.maxSubjectLength"); v != "" {
 		if n, err := strconv.Atoi(v); err == nil {
 			cfg.Commit.MaxSubjectLength = n
 		}
 	}
+	if v := runner.ConfigGet(ctx, "cx.commit.bodyWrap"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Commit.BodyWrap = n
+		}
+	}
+	if v := runner.ConfigGet(ctx, "cx.commit.normalizeBullets"); v != "" {
+		if b, ok := parseGitBool(v); ok {
+			cfg.Commit.NormalizeBullets = b
+		}
+	}
+	if v := runner.ConfigGet(ctx, "cx.commit.signoff"); v != "" {
+		if b, ok := parseGitBool(v); ok {
+			cfg.Commit.Signoff = b
+		}
+	}
+	if v := runner.ConfigGet(ctx, "cx.commit.gpgSign"); v != "" {
+		if b, ok := parseGitBool(v); ok {
+			cfg.Commit.GPGSign = b
+		}
+	}
 	if scopes := runner.ConfigGetAll(ctx, "cx.commit.scopes"); len(scopes) > 0 {
 		cfg.Commit.Scopes = scopes
 	}
+	if trailers := runner.ConfigGetAll(ctx, "cx.commit.trailers"); len(trailers) > 0 {
+		cfg.Commit.Trailers = trailers
+	}
 
-	return cfg
+	if err := applyTypeEntries(cfg, runner.ConfigGetRegexp(ctx, typesPattern)); err != nil {
+		return nil, fmt.Errorf("git config: %w", err)
+	}
+
+	// Lint
+	if types := runner.ConfigGetAll(ctx, "cx.lint.types"); len(types) > 0 {
+		cfg.Lint.Types = types
+	}
+	if v := runner.ConfigGet(ctx, "cx.lint.subjectCase"); v != "" {
+		cfg.Lint.SubjectCase = v
+	}
+	if v := runner.ConfigGet(ctx, "cx.lint.maxHeaderLength"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Lint.MaxHeaderLength = n
+		}
+	}
+	if v := runner.ConfigGet(ctx, "cx.lint.bodyMaxLineLength"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.Lint.BodyMaxLineLength = n
+		}
+	}
+	if rules := runner.ConfigGetAll(ctx, "cx.lint.disable"); len(rules) > 0 {
+		cfg.Lint.Disable = rules
+	}
+	if rules := runner.ConfigGetAll(ctx, "cx.lint.warn"); len(rules) > 0 {
+		cfg.Lint.Warn = rules
+	}
+
+	// Branch
+	if v := runner.ConfigGet(ctx, "cx.branch.pattern"); v != "" {
+		cfg.Branch.Pattern = v
+	}
+
+	// UI
+	if v := runner.ConfigGet(ctx, "cx.ui.theme"); v != "" {
+		cfg.UI.Theme = v
+	}
+	if v := runner.ConfigGet(ctx, "cx.ui.keymap"); v != "" {
+		cfg.UI.Keymap = v
+	}
+	applyUIEntries(cfg, runner.ConfigGetRegexp(ctx, uiPattern))
+
+	return cfg, nil
 }
 
 // Validate checks config values for consistency.
@@ -133,7 +259,34 @@ func (c *Config) Validate() error {
 	if c.Commit.MaxSubjectLength < 0 {
 		return fmt.Errorf("commit.maxSubjectLength must be >= 0")
 	}
-	return nil
+	if c.Commit.BodyWrap < 0 {
+		return fmt.Errorf("commit.bodyWrap must be >= 0")
+	}
+	switch c.Commit.EmojiPlacement {
+	case commit.EmojiPlacementSubject, commit.EmojiPlacementPrefix, commit.EmojiPlacementOnly:
+	default:
+		return fmt.Errorf("commit.emojiPlacement must be subject, prefix or only, got %q", c.Commit.EmojiPlacement)
+	}
+	switch c.Commit.EmojiFormat {
+	case commit.EmojiFormatUnicode, commit.EmojiFormatShortcode:
+	default:
+		return fmt.Errorf("commit.emojiFormat must be unicode or shortcode, got %q", c.Commit.EmojiFormat)
+	}
+	switch c.Lint.SubjectCase {
+	case "lower", "sentence", "any":
+	default:
+		return fmt.Errorf("lint.subjectCase must be lower, sentence or any, got %q", c.Lint.SubjectCase)
+	}
+	if c.Lint.MaxHeaderLength < 0 || c.Lint.BodyMaxLineLength < 0 {
+		return fmt.Errorf("lint.maxHeaderLength and lint.bodyMaxLineLength must be >= 0")
+	}
+	if err := c.validateTypes(); err != nil {
+		return err
+	}
+	if !strings.Contains(c.Branch.Pattern, "{slug}") {
+		return fmt.Errorf("branch.pattern must contain {slug}, got %q", c.Branch.Pattern)
+	}
+	return c.validateUI()
 }
 
 func validateBaseURL(raw string) error {
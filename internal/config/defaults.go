@@ -1,5 +1,7 @@
 package config
 
+import "github.com/hayatosc/git-cx/internal/commit"
+
 // DefaultConfig returns a Config populated with default values.
 func DefaultConfig() *Config {
 	return &Config{
@@ -7,12 +9,29 @@ func DefaultConfig() *Config {
 		Model:      "gemini-3.0-flash",
 		Candidates: 3,
 		Timeout:    30,
+		Types:      commit.DefaultTypes(),
 		API: APIConfig{
 			BaseURL: "https://api.openai.com/v1",
 		},
 		Commit: CommitConfig{
 			UseEmoji:         false,
+			EmojiPlacement:   commit.EmojiPlacementSubject,
+			EmojiFormat:      commit.EmojiFormatUnicode,
 			MaxSubjectLength: 100,
+			BodyWrap:         72,
+			NormalizeBullets: true,
+		},
+		Lint: LintConfig{
+			SubjectCase:       "lower",
+			MaxHeaderLength:   100,
+			BodyMaxLineLength: 100,
+		},
+		Branch: BranchConfig{
+			Pattern: "{type}/{slug}",
+		},
+		UI: UIConfig{
+			Theme:  "dark",
+			Keymap: "default",
 		},
 	}
 }
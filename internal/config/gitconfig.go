@@ -10,11 +10,8 @@ import (
 )
 
 func getFirstConfigValue(entries map[string][]string, key string) string {
-	if entries == nil {
-		return ""
-	}
-	values, ok := entries[key]
-	if !ok || len(values) == 0 {
+	values := getAllConfigValues(entries, key)
+	if len(values) == 0 {
 		return ""
 	}
 	return values[0]
@@ -24,11 +21,28 @@ func getAllConfigValues(entries map[string][]string, key string) []string {
 	if entries == nil {
 		return nil
 	}
-	values, ok := entries[key]
-	if !ok {
-		return nil
+	if values, ok := entries[key]; ok {
+		return values
+	}
+	// git config --list lowercases section and variable names.
+	want := canonicalConfigKey(key)
+	for k, values := range entries {
+		if canonicalConfigKey(k) == want {
+			return values
+		}
 	}
-	return values
+	return nil
+}
+
+// canonicalConfigKey lowercases the section and variable name of key while
+// keeping the (case-sensitive) subsection as is.
+func canonicalConfigKey(key string) string {
+	first := strings.Index(key, ".")
+	last := strings.LastIndex(key, ".")
+	if first < 0 {
+		return strings.ToLower(key)
+	}
+	return strings.ToLower(key[:first]) + key[first:last] + strings.ToLower(key[last:])
 }
 
 // ApplyGitConfigFile merges values from a gitconfig-format file into cfg.
@@ -64,6 +78,9 @@ func ApplyGitConfigFile(ctx context.Context, runner git.Runner, cfg *Config, pat
 	if v := getFirstConfigValue(entries, "cx.apiBaseUrl"); v != "" {
 		cfg.API.BaseURL = v
 	}
+	if coauthors := getAllConfigValues(entries, "cx.coauthors"); len(coauthors) > 0 {
+		cfg.CoAuthors = coauthors
+	}
 
 	if v := getFirstConfigValue(entries, "cx.commit.useEmoji"); v != "" {
 		b, err := parseBoolConfig("cx.commit.useEmoji", v)
@@ -72,6 +89,19 @@ func ApplyGitConfigFile(ctx context.Context, runner git.Runner, cfg *Config, pat
 		}
 		cfg.Commit.UseEmoji = b
 	}
+	if v := getFirstConfigValue(entries, "cx.commit.gitmoji"); v != "" {
+		b, err := parseBoolConfig("cx.commit.gitmoji", v)
+		if err != nil {
+			return err
+		}
+		cfg.Commit.Gitmoji = b
+	}
+	if v := getFirstConfigValue(entries, "cx.commit.emojiPlacement"); v != "" {
+		cfg.Commit.EmojiPlacement = v
+	}
+	if v := getFirstConfigValue(entries, "cx.commit.emojiFormat"); v != "" {
+		cfg.Commit.EmojiFormat = v
+	}
 	if v := getFirstConfigValue(entries, "cx.commit.maxSubjectLength"); v != "" {
 		n, err := parseIntConfig("cx.commit.maxSubjectLength", v)
 		if err != nil {
@@ -79,9 +109,79 @@ func ApplyGitConfigFile(ctx context.Context, runner git.Runner, cfg *Config, pat
 		}
 		cfg.Commit.MaxSubjectLength = n
 	}
+	if v := getFirstConfigValue(entries, "cx.commit.bodyWrap"); v != "" {
+		n, err := parseIntConfig("cx.commit.bodyWrap", v)
+		if err != nil {
+			return err
+		}
+		cfg.Commit.BodyWrap = n
+	}
+	if v := getFirstConfigValue(entries, "cx.commit.normalizeBullets"); v != "" {
+		b, err := parseBoolConfig("cx.commit.normalizeBullets", v)
+		if err != nil {
+			return err
+		}
+		cfg.Commit.NormalizeBullets = b
+	}
+	if v := getFirstConfigValue(entries, "cx.commit.signoff"); v != "" {
+		b, err := parseBoolConfig("cx.commit.signoff", v)
+		if err != nil {
+			return err
+		}
+		cfg.Commit.Signoff = b
+	}
+	if v := getFirstConfigValue(entries, "cx.commit.gpgSign"); v != "" {
+		b, err := parseBoolConfig("cx.commit.gpgSign", v)
+		if err != nil {
+			return err
+		}
+		cfg.Commit.GPGSign = b
+	}
 	if scopes := getAllConfigValues(entries, "cx.commit.scopes"); len(scopes) > 0 {
 		cfg.Commit.Scopes = scopes
 	}
+	if trailers := getAllConfigValues(entries, "cx.commit.trailers"); len(trailers) > 0 {
+		cfg.Commit.Trailers = trailers
+	}
+	if err := applyTypeEntries(cfg, entries); err != nil {
+		return err
+	}
+	if types := getAllConfigValues(entries, "cx.lint.types"); len(types) > 0 {
+		cfg.Lint.Types = types
+	}
+	if v := getFirstConfigValue(entries, "cx.lint.subjectCase"); v != "" {
+		cfg.Lint.SubjectCase = v
+	}
+	if v := getFirstConfigValue(entries, "cx.lint.maxHeaderLength"); v != "" {
+		n, err := parseIntConfig("cx.lint.maxHeaderLength", v)
+		if err != nil {
+			return err
+		}
+		cfg.Lint.MaxHeaderLength = n
+	}
+	if v := getFirstConfigValue(entries, "cx.lint.bodyMaxLineLength"); v != "" {
+		n, err := parseIntConfig("cx.lint.bodyMaxLineLength", v)
+		if err != nil {
+			return err
+		}
+		cfg.Lint.BodyMaxLineLength = n
+	}
+	if rules := getAllConfigValues(entries, "cx.lint.disable"); len(rules) > 0 {
+		cfg.Lint.Disable = rules
+	}
+	if rules := getAllConfigValues(entries, "cx.lint.warn"); len(rules) > 0 {
+		cfg.Lint.Warn = rules
+	}
+	if v := getFirstConfigValue(entries, "cx.branch.pattern"); v != "" {
+		cfg.Branch.Pattern = v
+	}
+	if v := getFirstConfigValue(entries, "cx.ui.theme"); v != "" {
+		cfg.UI.Theme = v
+	}
+	if v := getFirstConfigValue(entries, "cx.ui.keymap"); v != "" {
+		cfg.UI.Keymap = v
+	}
+	applyUIEntries(cfg, entries)
 	return nil
 }
 
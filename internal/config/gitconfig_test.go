@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/hayatosc/git-cx/internal/execx"
@@ -73,3 +74,181 @@ func TestLoadWithFile_GitConfigFormatMissingFile(t *testing.T) {
 		t.Fatalf("expected error for missing config file")
 	}
 }
+
+func TestLoadWithFile_LowercasedKeys(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00config\x00--file\x00/tmp/cx.conf\x00--list": {Stdout: "cx.commit.maxsubjectlength=80\ncx.lint.subjectcase=sentence\ncx.lint.disable=footer-token\n"},
+		},
+	}
+	cfg, err := LoadWithFile(context.Background(), git.NewRunnerWithExecutor(mock), "/tmp/cx.conf")
+	if err != nil {
+		t.Fatalf("LoadWithFile error: %v", err)
+	}
+	if cfg.Commit.MaxSubjectLength != 80 || cfg.Lint.SubjectCase != "sentence" {
+		t.Fatalf("lowercased keys not applied: %+v %+v", cfg.Commit, cfg.Lint)
+	}
+	if len(cfg.Lint.Disable) != 1 || cfg.Lint.Disable[0] != "footer-token" {
+		t.Fatalf("unexpected lint.disable: %#v", cfg.Lint.Disable)
+	}
+}
+
+func TestLoad_LintFromGitConfig(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00config\x00--get\x00cx.lint.subjectCase":     {Stdout: "any\n"},
+			"git\x00config\x00--get\x00cx.lint.maxHeaderLength": {Stdout: "72\n"},
+			"git\x00config\x00--get-all\x00cx.lint.warn":        {Stdout: "subject-full-stop\n"},
+		},
+	}
+	cfg, err := Load(context.Background(), git.NewRunnerWithExecutor(mock))
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if cfg.Lint.SubjectCase != "any" || cfg.Lint.MaxHeaderLength != 72 {
+		t.Fatalf("unexpected lint config: %+v", cfg.Lint)
+	}
+	if len(cfg.Lint.Warn) != 1 || cfg.Lint.Warn[0] != "subject-full-stop" {
+		t.Fatalf("unexpected lint.warn: %#v", cfg.Lint.Warn)
+	}
+}
+
+func TestLoadWithFile_CommitTypes(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00config\x00--file\x00/tmp/cx.conf\x00--list": {Stdout: "cx.types.sec.description=Security fixes\ncx.types.sec.emoji=🔒\ncx.types.sec.bump=patch\ncx.types.deps.description=Dependency updates\ncx.types.style.disabled\ncx.types.feat.emoji=🚀\ncx.types.order=sec\ncx.types.order=fix\n"},
+		},
+	}
+
+	cfg, err := LoadWithFile(context.Background(), git.NewRunnerWithExecutor(mock), "/tmp/cx.conf")
+	if err != nil {
+		t.Fatalf("LoadWithFile error: %v", err)
+	}
+	names := cfg.Types.Names()
+	want := []string{"sec", "fix", "feat", "docs", "refactor", "perf", "test", "build", "ci", "chore", "revert", "deps"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected types: %v", names)
+	}
+	sec, _ := cfg.Types.Lookup("sec")
+	if sec.Description != "Security fixes" || sec.Emoji != "🔒" || sec.Bump != "patch" {
+		t.Fatalf("unexpected sec type: %+v", sec)
+	}
+	if feat, _ := cfg.Types.Lookup("feat"); feat.Emoji != "🚀" || feat.Description != "A new feature" {
+		t.Fatalf("feat override lost defaults: %+v", feat)
+	}
+	if deps, _ := cfg.Types.Lookup("deps"); deps.Bump != "none" {
+		t.Fatalf("new type should not bump by default: %+v", deps)
+	}
+}
+
+func TestLoadWithFile_InvalidTypeBump(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00config\x00--file\x00/tmp/cx.conf\x00--list": {Stdout: "cx.types.sec.bump=huge\n"},
+		},
+	}
+
+	_, err := LoadWithFile(context.Background(), git.NewRunnerWithExecutor(mock), "/tmp/cx.conf")
+	if err == nil {
+		t.Fatalf("expected error for invalid bump")
+	}
+}
+
+func TestLoad_InvalidTypeDisabled(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00config\x00--get-regexp\x00^cx\\.types\\.": {Stdout: "cx.types.style.disabled maybe\n"},
+		},
+	}
+
+	_, err := Load(context.Background(), git.NewRunnerWithExecutor(mock))
+	if err == nil {
+		t.Fatalf("expected error for invalid cx.types.style.disabled")
+	}
+}
+
+func TestLoadWithFile_UnknownTypeOrder(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00config\x00--file\x00/tmp/cx.conf\x00--list": {Stdout: "cx.types.order=security\n"},
+		},
+	}
+
+	_, err := LoadWithFile(context.Background(), git.NewRunnerWithExecutor(mock), "/tmp/cx.conf")
+	if err == nil {
+		t.Fatalf("expected error for unknown type in cx.types.order")
+	}
+}
+
+func TestLoadWithFile_Gitmoji(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00config\x00--file\x00/tmp/cx.conf\x00--list": {Stdout: "cx.commit.gitmoji=true\ncx.commit.emojiplacement=only\ncx.commit.emojiformat=shortcode\n"},
+		},
+	}
+
+	cfg, err := LoadWithFile(context.Background(), git.NewRunnerWithExecutor(mock), "/tmp/cx.conf")
+	if err != nil {
+		t.Fatalf("LoadWithFile error: %v", err)
+	}
+	if !cfg.Commit.Gitmoji || cfg.Commit.EmojiPlacement != "only" || cfg.Commit.EmojiFormat != "shortcode" {
+		t.Fatalf("unexpected commit config: %+v", cfg.Commit)
+	}
+
+	cfg.Commit.EmojiPlacement = "suffix"
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for unknown emoji placement")
+	}
+}
+
+func TestLoadWithFile_CommitSigning(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00config\x00--file\x00/tmp/cx.conf\x00--list": {Stdout: "cx.commit.signoff=true\ncx.commit.gpgsign=yes\n"},
+		},
+	}
+
+	cfg, err := LoadWithFile(context.Background(), git.NewRunnerWithExecutor(mock), "/tmp/cx.conf")
+	if err != nil {
+		t.Fatalf("LoadWithFile error: %v", err)
+	}
+	if !cfg.Commit.Signoff || !cfg.Commit.GPGSign {
+		t.Fatalf("unexpected commit config: %+v", cfg.Commit)
+	}
+}
+
+func TestLoadWithFile_UI(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00config\x00--file\x00/tmp/cx.conf\x00--list": {Stdout: "cx.ui.theme=light\ncx.ui.keymap=vim\ncx.ui.color.dim=245\ncx.ui.color.diff-add=#00aa00\ncx.ui.key.commit=ctrl+s, y\ncx.ui.key.commit=enter\n"},
+		},
+	}
+
+	cfg, err := LoadWithFile(context.Background(), git.NewRunnerWithExecutor(mock), "/tmp/cx.conf")
+	if err != nil {
+		t.Fatalf("LoadWithFile error: %v", err)
+	}
+	if cfg.UI.Theme != "light" || cfg.UI.Keymap != "vim" || cfg.UI.Colors["dim"] != "245" || cfg.UI.Colors["diff-add"] != "#00aa00" {
+		t.Fatalf("unexpected ui config: %+v", cfg.UI)
+	}
+	if got := strings.Join(cfg.UI.Keys["commit"], " "); got != "ctrl+s y enter" {
+		t.Fatalf("unexpected commit keys: %q", got)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		modify func(*UIConfig)
+	}{
+		{"theme", func(ui *UIConfig) { ui.Theme = "solarized" }},
+		{"keymap", func(ui *UIConfig) { ui.Keymap = "emacs" }},
+		{"style", func(ui *UIConfig) { ui.Colors = map[string]string{"border-left": "1"} }},
+		{"colour", func(ui *UIConfig) { ui.Colors = map[string]string{"dim": "grey"} }},
+		{"action", func(ui *UIConfig) { ui.Keys = map[string][]string{"save": {"w"}} }},
+	} {
+		c := *cfg
+		tc.modify(&c.UI)
+		if err := c.Validate(); err == nil {
+			t.Errorf("%s: expected a validation error", tc.name)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hayatosc/git-cx/internal/commit"
+)
+
+// LintRules maps cx.lint.* settings onto commit.LintRules. Unknown rule
+// names in cx.lint.warn and cx.lint.disable are reported as an error.
+func (c *Config) LintRules() (commit.LintRules, error) {
+	rules := commit.DefaultLintRules()
+	if len(c.Types) > 0 {
+		rules.Types = c.Types.Names()
+	}
+	if len(c.Lint.Types) > 0 {
+		rules.Types = c.Lint.Types
+	}
+	rules.Scopes = c.Commit.Scopes
+	rules.SubjectCase = c.Lint.SubjectCase
+	rules.MaxHeaderLength = c.Lint.MaxHeaderLength
+	rules.BodyMaxLineLength = c.Lint.BodyMaxLineLength
+	rules.Levels = map[string]commit.Level{}
+	for _, set := range []struct {
+		names []string
+		level commit.Level
+	}{
+		{c.Lint.Warn, commit.LevelWarning},
+		{c.Lint.Disable, commit.LevelOff},
+	} {
+		for _, name := range set.names {
+			if !slices.Contains(commit.LintRuleNames, name) {
+				return rules, fmt.Errorf("unknown lint rule %q (valid rules: %s)", name, strings.Join(commit.LintRuleNames, ", "))
+			}
+			rules.Levels[name] = set.level
+		}
+	}
+	return rules, nil
+}
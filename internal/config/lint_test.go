@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/hayatosc/git-cx/internal/commit"
+)
+
+func TestLintRules(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Commit.Scopes = []string{"core"}
+	cfg.Lint.Warn = []string{"subject-case"}
+	cfg.Lint.Disable = []string{"footer-token"}
+
+	rules, err := cfg.LintRules()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rules.Scopes) != 1 || rules.Levels["subject-case"] != commit.LevelWarning || rules.Levels["footer-token"] != commit.LevelOff {
+		t.Fatalf("unexpected rules: %+v", rules)
+	}
+
+	cfg.Lint.Disable = []string{"no-such-rule"}
+	if _, err := cfg.LintRules(); err == nil {
+		t.Fatal("expected error for unknown rule")
+	}
+}
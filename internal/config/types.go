@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hayatosc/git-cx/internal/commit"
+)
+
+// typesPattern matches the cx.types.* keys for git config --get-regexp.
+const typesPattern = `^cx\.types\.`
+
+// applyTypeEntries merges cx.types.<name>.{description,emoji,bump,disabled}
+// and cx.types.order from entries into cfg.Types. Types that are not built in
+// are appended in name order; cx.types.order moves the listed types to the
+// front. Invalid values are skipped and the first one is returned as error.
+func applyTypeEntries(cfg *Config, entries map[string][]string) error {
+	var firstErr error
+	overrides := map[string]map[string]string{}
+	for key, values := range entries {
+		if len(values) == 0 {
+			continue
+		}
+		rest, ok := strings.CutPrefix(key, "cx.types.")
+		if !ok {
+			continue
+		}
+		dot := strings.LastIndex(rest, ".")
+		if dot <= 0 {
+			continue
+		}
+		name, field := rest[:dot], strings.ToLower(rest[dot+1:])
+		if overrides[name] == nil {
+			overrides[name] = map[string]string{}
+		}
+		overrides[name][field] = values[len(values)-1]
+	}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fields := overrides[name]
+		i := slices.IndexFunc(cfg.Types, func(t commit.Type) bool { return t.Name == name })
+		if i < 0 {
+			cfg.Types = append(cfg.Types, commit.Type{Name: name, Bump: commit.BumpNone})
+			i = len(cfg.Types) - 1
+		}
+		t := &cfg.Types[i]
+		if v, ok := fields["description"]; ok {
+			t.Description = v
+		}
+		if v, ok := fields["emoji"]; ok {
+			t.Emoji = v
+		}
+		if v, ok := fields["bump"]; ok {
+			t.Bump = strings.ToLower(strings.TrimSpace(v))
+		}
+		if v, ok := fields["disabled"]; ok {
+			disabled, valid := true, true
+			if v != "" { // a key without "=" means true in git config
+				disabled, valid = parseGitBool(v)
+			}
+			if !valid && firstErr == nil {
+				firstErr = fmt.Errorf("invalid cx.types.%s.disabled %q: expected true/false/on/off/yes/no", name, v)
+			}
+			if disabled {
+				cfg.Types = slices.Delete(cfg.Types, i, i+1)
+			}
+		}
+	}
+
+	if order := getAllConfigValues(entries, "cx.types.order"); len(order) > 0 {
+		cfg.TypeOrder = order
+		cfg.Types = orderTypes(cfg.Types, order)
+	}
+	return firstErr
+}
+
+// orderTypes moves the types named in order to the front, keeping the
+// relative order of the rest. Unknown names are left for Validate to report.
+func orderTypes(types commit.Types, order []string) commit.Types {
+	ordered := make(commit.Types, 0, len(types))
+	for _, name := range order {
+		if t, ok := types.Lookup(name); ok && !slices.Contains(ordered.Names(), name) {
+			ordered = append(ordered, t)
+		}
+	}
+	for _, t := range types {
+		if !slices.Contains(order, t.Name) {
+			ordered = append(ordered, t)
+		}
+	}
+	return ordered
+}
+
+// validateTypes checks the resolved commit types and cx.types.order.
+func (c *Config) validateTypes() error {
+	if len(c.Types) == 0 {
+		return fmt.Errorf("cx.types: at least one commit type must be enabled")
+	}
+	for _, t := range c.Types {
+		if t.Name == commit.AutoType || strings.ContainsAny(t.Name, " ():!") {
+			return fmt.Errorf("cx.types: invalid type name %q", t.Name)
+		}
+		switch t.Bump {
+		case commit.BumpNone, commit.BumpPatch, commit.BumpMinor, commit.BumpMajor:
+		default:
+			return fmt.Errorf("cx.types.%s.bump must be none, patch, minor or major, got %q", t.Name, t.Bump)
+		}
+	}
+	for _, name := range c.TypeOrder {
+		if _, ok := c.Types.Lookup(name); !ok {
+			return fmt.Errorf("cx.types.order: unknown or disabled type %q", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// uiPattern matches the cx.ui.color.* and cx.ui.key.* keys for git config
+// --get-regexp.
+const uiPattern = `^cx\.ui\.(color|key)\.`
+
+// UIThemes lists the values of cx.ui.theme. "custom" starts from the
+// terminal's default colours.
+var UIThemes = []string{"dark", "light", "high-contrast", "custom"}
+
+// UIKeymaps lists the values of cx.ui.keymap.
+var UIKeymaps = []string{"default", "vim"}
+
+// UIStyles lists the styles cx.ui.color.<style> sets.
+var UIStyles = []string{"title", "subtitle", "selected", "dim", "error", "border", "help", "diff-add", "diff-del", "diff-add-bg", "diff-del-bg", "diff-hunk", "match"}
+
+// UIKeyActions lists the actions cx.ui.key.<action> binds. The TUI maps each
+// name to a key binding; its tests fail when a binding or a name is missing.
+var UIKeyActions = []string{
+	"up", "down", "select", "back", "quit", "help",
+	"diff", "editor", "retry-ai", "edit", "done", "next-field", "prev-field",
+	"commit", "abort", "breaking", "retry", "no-verify",
+	"add-trailer", "co-author", "edit-trailer", "delete-trailer",
+	"close-diff", "next-file", "prev-file", "search", "next-match", "prev-match", "top", "bottom",
+}
+
+// colorPattern matches an ANSI colour number or a hex colour.
+var colorPattern = regexp.MustCompile(`^([0-9]{1,3}|#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6})$`)
+
+// applyUIEntries merges cx.ui.color.<style> and cx.ui.key.<action> from
+// entries into cfg.UI. Keys are comma-separated and may be given several
+// times; names and values are checked by Validate.
+func applyUIEntries(cfg *Config, entries map[string][]string) {
+	for key, values := range entries {
+		if len(values) == 0 {
+			continue
+		}
+		if style, ok := strings.CutPrefix(strings.ToLower(key), "cx.ui.color."); ok {
+			if cfg.UI.Colors == nil {
+				cfg.UI.Colors = map[string]string{}
+			}
+			cfg.UI.Colors[style] = strings.TrimSpace(values[len(values)-1])
+			continue
+		}
+		if action, ok := strings.CutPrefix(strings.ToLower(key), "cx.ui.key."); ok {
+			var keys []string
+			for _, v := range values {
+				for _, k := range strings.Split(v, ",") {
+					if k = strings.TrimSpace(k); k != "" {
+						keys = append(keys, k)
+					}
+				}
+			}
+			if cfg.UI.Keys == nil {
+				cfg.UI.Keys = map[string][]string{}
+			}
+			cfg.UI.Keys[action] = keys
+		}
+	}
+}
+
+// validateUI checks the theme, colours and key bindings.
+func (c *Config) validateUI() error {
+	if !slices.Contains(UIThemes, c.UI.Theme) {
+		return fmt.Errorf("ui.theme must be %s, got %q", strings.Join(UIThemes, ", "), c.UI.Theme)
+	}
+	if !slices.Contains(UIKeymaps, c.UI.Keymap) {
+		return fmt.Errorf("ui.keymap must be %s, got %q", strings.Join(UIKeymaps, " or "), c.UI.Keymap)
+	}
+	for style, color := range c.UI.Colors {
+		if !slices.Contains(UIStyles, style) {
+			return fmt.Errorf("cx.ui.color.%s: unknown style (valid styles: %s)", style, strings.Join(UIStyles, ", "))
+		}
+		if !colorPattern.MatchString(color) {
+			return fmt.Errorf("cx.ui.color.%s must be an ANSI colour number or #rrggbb, got %q", style, color)
+		}
+	}
+	for action, keys := range c.UI.Keys {
+		if !slices.Contains(UIKeyActions, action) {
+			return fmt.Errorf("cx.ui.key.%s: unknown action (valid actions: %s)", action, strings.Join(UIKeyActions, ", "))
+		}
+		if len(keys) == 0 {
+			return fmt.Errorf("cx.ui.key.%s: no keys given", action)
+		}
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"os/exec"
+	"strings"
+	"time"
 )
 
 // Result holds stdout and stderr output.
@@ -15,15 +17,48 @@ type Result struct {
 // Runner executes commands and returns captured output.
 type Runner interface {
 	Run(ctx context.Context, name string, args ...string) (Result, error)
+	// RunInput is like Run but feeds input to the command's stdin.
+	RunInput(ctx context.Context, input, name string, args ...string) (Result, error)
 	RunShell(ctx context.Context, command string) (Result, error)
 }
 
 // DefaultRunner executes commands via os/exec.
 type DefaultRunner struct{}
 
+// processGroupKey marks contexts created by WithProcessGroup.
+type processGroupKey struct{}
+
+// WithProcessGroup makes commands run under ctx start in their own process
+// group, which is killed as a whole when ctx is done, so that children of a
+// cancelled command (e.g. what a CLI provider or sh -c spawns) do not outlive
+// it. Terminal signals such as Ctrl+C no longer reach such commands, so use
+// it only where cancellation comes from the program itself, like a TUI in
+// raw mode.
+func WithProcessGroup(ctx context.Context) context.Context {
+	return context.WithValue(ctx, processGroupKey{}, true)
+}
+
+// waitDelay bounds how long a killed process group may keep the output
+// pipes open.
+const waitDelay = 2 * time.Second
+
 // Run executes a command with arguments.
 func (DefaultRunner) Run(ctx context.Context, name string, args ...string) (Result, error) {
+	return run(ctx, exec.CommandContext(ctx, name, args...))
+}
+
+// RunInput executes a command with input on its stdin.
+func (DefaultRunner) RunInput(ctx context.Context, input, name string, args ...string) (Result, error) {
 	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	return run(ctx, cmd)
+}
+
+func run(ctx context.Context, cmd *exec.Cmd) (Result, error) {
+	if group, _ := ctx.Value(processGroupKey{}).(bool); group {
+		setProcessGroup(cmd)
+		cmd.WaitDelay = waitDelay
+	}
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -0,0 +1,38 @@
+//go:build unix
+
+package execx
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRun_processGroupKilledOnCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(WithProcessGroup(context.Background()), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	res, err := DefaultRunner{}.RunShell(ctx, "sleep 30 & echo $!; wait")
+	if err == nil {
+		t.Fatal("expected the command to be killed")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("command took %s to stop", elapsed)
+	}
+	pid, convErr := strconv.Atoi(strings.TrimSpace(res.Stdout))
+	if convErr != nil {
+		t.Fatalf("unexpected output %q: %v", res.Stdout, convErr)
+	}
+	// The background sleep must be gone too; give the kernel a moment to reap it.
+	deadline := time.Now().Add(2 * time.Second)
+	for syscall.Kill(pid, 0) == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("child process %d outlived the cancelled command", pid)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -15,14 +15,20 @@ type MockRunner struct {
 
 // Call records a command invocation.
 type Call struct {
-	Name string
-	Args []string
+	Name  string
+	Args  []string
+	Input string // stdin passed to RunInput
 }
 
 // Run executes a command returning canned results.
 func (m *MockRunner) Run(ctx context.Context, name string, args ...string) (Result, error) {
+	return m.RunInput(ctx, "", name, args...)
+}
+
+// RunInput records input and returns canned results keyed by name and args.
+func (m *MockRunner) RunInput(ctx context.Context, input, name string, args ...string) (Result, error) {
 	_ = ctx
-	m.Calls = append(m.Calls, Call{Name: name, Args: append([]string{}, args...)})
+	m.Calls = append(m.Calls, Call{Name: name, Args: append([]string{}, args...), Input: input})
 	key := buildKey(name, args)
 	if err, ok := m.Errors[key]; ok {
 		return Result{}, err
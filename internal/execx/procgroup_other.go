@@ -0,0 +1,9 @@
+//go:build !unix
+
+package execx
+
+import "os/exec"
+
+// setProcessGroup is a no-op where process groups are not supported;
+// cancelling kills only the command itself.
+func setProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,63 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StagedFiles lists the paths with staged changes.
+func (r Runner) StagedFiles(ctx context.Context) ([]string, error) {
+	out, err := r.run(ctx, "git", "diff", "--cached", "--name-only")
+	if err != nil {
+		return nil, fmt.Errorf("git diff --cached --name-only: %w", err)
+	}
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// RecentAuthors returns the authors ("Name <email>", after .mailmap) of the
+// last n commits that touched paths, or of the last n commits overall when
+// paths is empty. The most frequent authors come first; bots are skipped.
+func (r Runner) RecentAuthors(ctx context.Context, paths []string, n int) ([]string, error) {
+	args := []string{"log", fmt.Sprintf("--max-count=%d", n), "--format=%aN <%aE>", "--"}
+	out, err := r.run(ctx, "git", append(args, paths...)...)
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+	var authors []string
+	counts := map[string]int{}
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.Contains(line, "[bot]") {
+			continue
+		}
+		key := strings.ToLower(IdentEmail(line))
+		if counts[key] == 0 {
+			authors = append(authors, line)
+		}
+		counts[key]++
+	}
+	// Stable, so equally frequent authors stay in order of recency.
+	sort.SliceStable(authors, func(i, j int) bool {
+		return counts[strings.ToLower(IdentEmail(authors[i]))] > counts[strings.ToLower(IdentEmail(authors[j]))]
+	})
+	return authors, nil
+}
+
+// IdentEmail returns the email of a "Name <email>" identity, or the whole
+// string when it has no angle brackets.
+func IdentEmail(ident string) string {
+	start := strings.LastIndex(ident, "<")
+	end := strings.LastIndex(ident, ">")
+	if start < 0 || end < start {
+		return ident
+	}
+	return ident[start+1 : end]
+}
@@ -0,0 +1,25 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hayatosc/git-cx/internal/execx"
+)
+
+func TestRecentAuthors(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00log\x00--max-count=50\x00--format=%aN <%aE>\x00--\x00a.go\x00b.go": {
+				Stdout: "Ann <ann@example.com>\nBob <bob@example.com>\ndependabot[bot] <bot@example.com>\nBob <BOB@example.com>\n",
+			},
+		},
+	}
+	got, err := NewRunnerWithExecutor(mock).RecentAuthors(context.Background(), []string{"a.go", "b.go"}, 50)
+	if err != nil {
+		t.Fatalf("RecentAuthors error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "Bob <bob@example.com>" || got[1] != "Ann <ann@example.com>" {
+		t.Fatalf("unexpected authors: %#v", got)
+	}
+}
@@ -84,6 +84,65 @@ func (r Runner) LastCommitStat(ctx context.Context) (string, error) {
 	return strings.TrimSpace(out), nil
 }
 
+// BranchDiff returns the diff of HEAD against its merge base with base.
+func (r Runner) BranchDiff(ctx context.Context, base string) (string, error) {
+	out, err := r.run(ctx, "git", "diff", "--no-color", base+"...HEAD")
+	if err != nil {
+		return "", fmt.Errorf("git diff %s...HEAD: %w", base, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// BranchStat returns the --stat of HEAD against its merge base with base.
+func (r Runner) BranchStat(ctx context.Context, base string) (string, error) {
+	out, err := r.run(ctx, "git", "diff", "--stat", "--no-color", base+"...HEAD")
+	if err != nil {
+		return "", fmt.Errorf("git diff --stat %s...HEAD: %w", base, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// BaseBranch returns the branch HEAD is compared with for a pull request:
+// the upstream of the current branch when it tracks another branch (e.g.
+// "origin/develop"), otherwise DefaultBranch. An upstream with the current
+// branch's own name is where the branch is pushed, not its base.
+func (r Runner) BaseBranch(ctx context.Context) string {
+	upstream, err := r.run(ctx, "git", "rev-parse", "--symbolic-full-name", "@{upstream}")
+	upstream = strings.TrimSpace(upstream)
+	branch, _ := r.run(ctx, "git", "symbolic-ref", "--quiet", "--short", "HEAD")
+	branch = strings.TrimSpace(branch)
+	if err == nil && branch != "" {
+		if name, ok := strings.CutPrefix(upstream, "refs/heads/"); ok && name != branch {
+			return name
+		}
+		if short, ok := strings.CutPrefix(upstream, "refs/remotes/"); ok {
+			if _, name, _ := strings.Cut(short, "/"); name != "" && name != branch {
+				return short
+			}
+		}
+	}
+	return r.DefaultBranch(ctx)
+}
+
+// DefaultBranch returns the remote default branch (e.g. "origin/main"),
+// falling back to "main" when origin/HEAD is not set.
+func (r Runner) DefaultBranch(ctx context.Context) string {
+	out, err := r.run(ctx, "git", "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	if err != nil || strings.TrimSpace(out) == "" {
+		return "main"
+	}
+	return strings.TrimSpace(out)
+}
+
+// TopLevel returns the absolute path of the working tree root.
+func (r Runner) TopLevel(ctx context.Context) (string, error) {
+	out, err := r.run(ctx, "git", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse --show-toplevel: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
 func joinOutput(stdout, stderr string) string {
 	switch {
 	case stdout == "":
@@ -95,9 +154,46 @@ func joinOutput(stdout, stderr string) string {
 	}
 }
 
-// Commit executes `git commit -m <message>` and returns combined output.
-func (r Runner) Commit(ctx context.Context, message string) (string, error) {
-	result, err := r.runner.Run(ctx, "git", "commit", "-m", message)
+// CommitOptions are extra flags passed through to git commit.
+type CommitOptions struct {
+	Signoff    bool   // --signoff
+	GPGSign    bool   // -S
+	NoVerify   bool   // --no-verify
+	AllowEmpty bool   // --allow-empty
+	Author     string // --author
+	Date       string // --date
+}
+
+// args returns the git commit flags for o.
+func (o CommitOptions) args() []string {
+	var args []string
+	if o.Signoff {
+		args = append(args, "--signoff")
+	}
+	if o.GPGSign {
+		args = append(args, "-S")
+	}
+	if o.NoVerify {
+		args = append(args, "--no-verify")
+	}
+	if o.AllowEmpty {
+		args = append(args, "--allow-empty")
+	}
+	if o.Author != "" {
+		args = append(args, "--author="+o.Author)
+	}
+	if o.Date != "" {
+		args = append(args, "--date="+o.Date)
+	}
+	return args
+}
+
+// Commit executes `git commit -F -` with message on stdin and returns
+// combined output. On failure the output holds everything git and its hooks
+// printed.
+func (r Runner) Commit(ctx context.Context, message string, opts CommitOptions) (string, error) {
+	args := append([]string{"commit", "-F", "-"}, opts.args()...)
+	result, err := r.runner.RunInput(ctx, message, "git", args...)
 	output := strings.TrimSpace(joinOutput(result.Stdout, result.Stderr))
 	if err != nil {
 		msg := strings.TrimSpace(result.Stderr)
@@ -112,6 +208,135 @@ func (r Runner) Commit(ctx context.Context, message string) (string, error) {
 	return output, nil
 }
 
+// Editor returns the editor git would open for a commit message, honouring
+// GIT_EDITOR, core.editor, VISUAL and EDITOR in that order.
+func (r Runner) Editor(ctx context.Context) (string, error) {
+	out, err := r.run(ctx, "git", "var", "GIT_EDITOR")
+	if err != nil {
+		return "", fmt.Errorf("git var GIT_EDITOR: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// CommentChar returns core.commentChar, the prefix of the comment lines git
+// strips from a message: "#" when unset, "auto" when git picks one per
+// message.
+func (r Runner) CommentChar(ctx context.Context) string {
+	if v := r.ConfigGet(ctx, "core.commentChar"); v != "" {
+		return v
+	}
+	return "#"
+}
+
+// Head returns the hash of the HEAD commit, or "" on a branch without commits.
+func (r Runner) Head(ctx context.Context) string {
+	out, err := r.run(ctx, "git", "rev-parse", "--verify", "--quiet", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// StagedTree writes the index as a tree object and returns its hash, which
+// identifies the staged changes.
+func (r Runner) StagedTree(ctx context.Context) (string, error) {
+	out, err := r.run(ctx, "git", "write-tree")
+	if err != nil {
+		return "", fmt.Errorf("git write-tree: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// GitPath resolves path inside the git directory, e.g. "cx/draft.json" to
+// ".git/cx/draft.json". The result is relative to the current working
+// directory unless absolute.
+func (r Runner) GitPath(ctx context.Context, path string) (string, error) {
+	out, err := r.run(ctx, "git", "rev-parse", "--git-path", path)
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse --git-path %s: %w", path, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// HooksDir returns the directory git runs hooks from, honouring core.hooksPath.
+// The path is relative to the current working directory unless absolute.
+func (r Runner) HooksDir(ctx context.Context) (string, error) {
+	out, err := r.run(ctx, "git", "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse --git-path hooks: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// LogEntry is a commit returned by Log.
+type LogEntry struct {
+	Hash    string
+	Message string
+}
+
+// Log returns the commits in revRange (e.g. "v1.0.0..HEAD"), newest first.
+func (r Runner) Log(ctx context.Context, revRange string) ([]LogEntry, error) {
+	out, err := r.run(ctx, "git", "log", "--format=%H%x00%B%x1e", revRange, "--")
+	if err != nil {
+		return nil, fmt.Errorf("git log %s: %w", revRange, err)
+	}
+	var entries []LogEntry
+	for _, record := range strings.Split(out, "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		hash, message, ok := strings.Cut(record, "\x00")
+		if !ok {
+			continue
+		}
+		entries = append(entries, LogEntry{Hash: hash, Message: strings.TrimSpace(message)})
+	}
+	return entries, nil
+}
+
+// CommitMessage returns the full message of a single commit.
+func (r Runner) CommitMessage(ctx context.Context, rev string) (LogEntry, error) {
+	out, err := r.run(ctx, "git", "log", "-1", "--format=%H%x00%B", rev, "--")
+	if err != nil {
+		return LogEntry{}, fmt.Errorf("git log -1 %s: %w", rev, err)
+	}
+	hash, message, _ := strings.Cut(out, "\x00")
+	return LogEntry{Hash: strings.TrimSpace(hash), Message: strings.TrimSpace(message)}, nil
+}
+
+// LatestTag returns the most recent tag reachable from HEAD, or "" if there is none.
+func (r Runner) LatestTag(ctx context.Context) (string, error) {
+	out, err := r.run(ctx, "git", "describe", "--tags", "--abbrev=0")
+	if err != nil {
+		if strings.Contains(err.Error(), "No names found") || strings.Contains(err.Error(), "No tags can describe") {
+			return "", nil
+		}
+		return "", fmt.Errorf("git describe --tags: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// MergedTags lists the tags reachable from HEAD.
+func (r Runner) MergedTags(ctx context.Context) ([]string, error) {
+	out, err := r.run(ctx, "git", "tag", "--list", "--merged", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("git tag --merged: %w", err)
+	}
+	var tags []string
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			tags = append(tags, line)
+		}
+	}
+	return tags, nil
+}
+
+// CreateAnnotatedTag creates an annotated tag at HEAD with message kept verbatim.
+func (r Runner) CreateAnnotatedTag(ctx context.Context, name, message string) error {
+	if _, err := r.run(ctx, "git", "tag", "--annotate", "--cleanup=verbatim", "-m", message, name); err != nil {
+		return fmt.Errorf("git tag %s: %w", name, err)
+	}
+	return nil
+}
+
 // ConfigGet reads a git config value. Returns "" if not set.
 func (r Runner) ConfigGet(ctx context.Context, key string) string {
 	out, err := r.run(ctx, "git", "config", "--get", key)
@@ -121,6 +346,29 @@ func (r Runner) ConfigGet(ctx context.Context, key string) string {
 	return strings.TrimSpace(out)
 }
 
+// ConfigGetRegexp reads all entries whose key matches pattern, keyed as git
+// prints them (section and variable names lowercased). Returns nil if none
+// match.
+func (r Runner) ConfigGetRegexp(ctx context.Context, pattern string) map[string][]string {
+	out, err := r.run(ctx, "git", "config", "--get-regexp", pattern)
+	if err != nil {
+		return nil
+	}
+	entries := make(map[string][]string)
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(line, " ")
+		entries[key] = append(entries[key], value)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	return entries
+}
+
 // ConfigGetFromFile reads a git config value from a config file.
 // Returns "" and nil if the key is not set.
 func (r Runner) ConfigGetFromFile(ctx context.Context, path, key string) (string, error) {
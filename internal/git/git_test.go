@@ -48,6 +48,25 @@ func TestConfigGetAll(t *testing.T) {
 	}
 }
 
+func TestConfigGetRegexp(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00config\x00--get-regexp\x00^cx\\.types\\.": {Stdout: "cx.types.sec.description Security fixes\ncx.types.order sec\ncx.types.order feat\ncx.types.style.disabled\n"},
+		},
+	}
+	runner := NewRunnerWithExecutor(mock)
+	got := runner.ConfigGetRegexp(context.Background(), `^cx\.types\.`)
+	if v := got["cx.types.sec.description"]; len(v) != 1 || v[0] != "Security fixes" {
+		t.Fatalf("unexpected description: %#v", got)
+	}
+	if v := got["cx.types.order"]; len(v) != 2 || v[0] != "sec" || v[1] != "feat" {
+		t.Fatalf("unexpected order: %#v", got)
+	}
+	if v := got["cx.types.style.disabled"]; len(v) != 1 || v[0] != "" {
+		t.Fatalf("unexpected valueless entry: %#v", got)
+	}
+}
+
 func TestConfigGetFromFile(t *testing.T) {
 	mock := &execx.MockRunner{
 		Results: map[string]execx.Result{
@@ -215,7 +234,7 @@ func TestCommit_success_returnsCombinedOutput(t *testing.T) {
 	runner := NewRunnerWithExecutor(stubRunner{
 		result: execx.Result{Stdout: "created", Stderr: "hook log"},
 	})
-	out, err := runner.Commit(context.Background(), "feat: ok")
+	out, err := runner.Commit(context.Background(), "feat: ok", CommitOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -229,7 +248,7 @@ func TestCommit_error_includesOutputAndMessage(t *testing.T) {
 		result: execx.Result{Stderr: "lint failed\n"},
 		err:    errors.New("exit status 1"),
 	})
-	out, err := runner.Commit(context.Background(), "feat: fail")
+	out, err := runner.Commit(context.Background(), "feat: fail", CommitOptions{})
 	if out != "lint failed" {
 		t.Fatalf("unexpected output: %q", out)
 	}
@@ -244,6 +263,54 @@ func TestCommit_error_includesOutputAndMessage(t *testing.T) {
 	}
 }
 
+func TestCommit_passesMessageOnStdinWithOptions(t *testing.T) {
+	mock := &execx.MockRunner{}
+	runner := NewRunnerWithExecutor(mock)
+	opts := CommitOptions{Signoff: true, GPGSign: true, NoVerify: true, AllowEmpty: true, Author: "A <a@example.com>", Date: "2024-01-02"}
+	if _, err := runner.Commit(context.Background(), "feat: ok\n\n-m looks like a flag", opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mock.Calls) != 1 {
+		t.Fatalf("expected one call, got %d", len(mock.Calls))
+	}
+	call := mock.Calls[0]
+	want := []string{"commit", "-F", "-", "--signoff", "-S", "--no-verify", "--allow-empty", "--author=A <a@example.com>", "--date=2024-01-02"}
+	if strings.Join(call.Args, "\x00") != strings.Join(want, "\x00") {
+		t.Fatalf("unexpected args: %q", call.Args)
+	}
+	if call.Input != "feat: ok\n\n-m looks like a flag" {
+		t.Fatalf("unexpected stdin: %q", call.Input)
+	}
+}
+
+func TestEditor(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00var\x00GIT_EDITOR": {Stdout: "code --wait\n"},
+		},
+	}
+	got, err := NewRunnerWithExecutor(mock).Editor(context.Background())
+	if err != nil || got != "code --wait" {
+		t.Fatalf("Editor() = %q, %v", got, err)
+	}
+}
+
+func TestStagedTreeAndGitPath(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00write-tree": {Stdout: "4b825dc\n"},
+			"git\x00rev-parse\x00--git-path\x00cx/draft.json": {Stdout: ".git/cx/draft.json\n"},
+		},
+	}
+	runner := NewRunnerWithExecutor(mock)
+	if got, err := runner.StagedTree(context.Background()); err != nil || got != "4b825dc" {
+		t.Fatalf("StagedTree() = %q, %v", got, err)
+	}
+	if got, err := runner.GitPath(context.Background(), "cx/draft.json"); err != nil || got != ".git/cx/draft.json" {
+		t.Fatalf("GitPath() = %q, %v", got, err)
+	}
+}
+
 type stubRunner struct {
 	result execx.Result
 	err    error
@@ -253,6 +320,124 @@ func (s stubRunner) Run(ctx context.Context, name string, args ...string) (execx
 	return s.result, s.err
 }
 
+func (s stubRunner) RunInput(ctx context.Context, _, name string, args ...string) (execx.Result, error) {
+	return s.Run(ctx, name, args...)
+}
+
 func (s stubRunner) RunShell(ctx context.Context, command string) (execx.Result, error) {
 	return s.Run(ctx, "sh", "-c", command)
 }
+
+func TestLog(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00log\x00--format=%H%x00%B%x1e\x00v1.0.0..HEAD\x00--": {Stdout: "abc\x00feat: a\n\nbody\n\x1e\ndef\x00fix: b\n\x1e\n"},
+		},
+	}
+	runner := NewRunnerWithExecutor(mock)
+	got, err := runner.Log(context.Background(), "v1.0.0..HEAD")
+	if err != nil {
+		t.Fatalf("Log error: %v", err)
+	}
+	if len(got) != 2 || got[0].Hash != "abc" || got[0].Message != "feat: a\n\nbody" || got[1].Message != "fix: b" {
+		t.Fatalf("unexpected log: %#v", got)
+	}
+}
+
+func TestLatestTag_NoTags(t *testing.T) {
+	mock := &execx.MockRunner{
+		Errors: map[string]error{
+			"git\x00describe\x00--tags\x00--abbrev=0": errors.New("fatal: No names found, cannot describe anything."),
+		},
+	}
+	runner := NewRunnerWithExecutor(mock)
+	got, err := runner.LatestTag(context.Background())
+	if err != nil || got != "" {
+		t.Fatalf("expected no tag and no error, got %q %v", got, err)
+	}
+}
+
+func TestMergedTags(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00tag\x00--list\x00--merged\x00HEAD": {Stdout: "v0.1.0\nv0.2.0\n"},
+		},
+	}
+	runner := NewRunnerWithExecutor(mock)
+	got, err := runner.MergedTags(context.Background())
+	if err != nil {
+		t.Fatalf("MergedTags error: %v", err)
+	}
+	if len(got) != 2 || got[1] != "v0.2.0" {
+		t.Fatalf("unexpected tags: %#v", got)
+	}
+}
+
+func TestDefaultBranch(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00symbolic-ref\x00--quiet\x00--short\x00refs/remotes/origin/HEAD": {Stdout: "origin/develop\n"},
+		},
+	}
+	if got := NewRunnerWithExecutor(mock).DefaultBranch(context.Background()); got != "origin/develop" {
+		t.Fatalf("unexpected default branch: %q", got)
+	}
+	failing := &execx.MockRunner{
+		Errors: map[string]error{
+			"git\x00symbolic-ref\x00--quiet\x00--short\x00refs/remotes/origin/HEAD": exitError{code: 1},
+		},
+	}
+	if got := NewRunnerWithExecutor(failing).DefaultBranch(context.Background()); got != "main" {
+		t.Fatalf("expected fallback to main, got %q", got)
+	}
+}
+
+func TestBaseBranch(t *testing.T) {
+	const upstreamKey = "git\x00rev-parse\x00--symbolic-full-name\x00@{upstream}"
+	const branchKey = "git\x00symbolic-ref\x00--quiet\x00--short\x00HEAD"
+	const defaultKey = "git\x00symbolic-ref\x00--quiet\x00--short\x00refs/remotes/origin/HEAD"
+	tests := []struct {
+		upstream, want string
+	}{
+		{"refs/remotes/origin/develop\n", "origin/develop"},
+		{"refs/heads/release\n", "release"},
+		{"refs/remotes/origin/topic\n", "origin/main"}, // the branch's own remote copy
+		{"", "origin/main"},
+	}
+	for _, tt := range tests {
+		mock := &execx.MockRunner{Results: map[string]execx.Result{
+			upstreamKey: {Stdout: tt.upstream},
+			branchKey:   {Stdout: "topic\n"},
+			defaultKey:  {Stdout: "origin/main\n"},
+		}}
+		if tt.upstream == "" {
+			mock.Errors = map[string]error{upstreamKey: exitError{code: 128}}
+		}
+		if got := NewRunnerWithExecutor(mock).BaseBranch(context.Background()); got != tt.want {
+			t.Errorf("upstream %q: got %q, want %q", tt.upstream, got, tt.want)
+		}
+	}
+}
+
+func TestCommentChar(t *testing.T) {
+	const key = "git\x00config\x00--get\x00core.commentChar"
+	if got := NewRunnerWithExecutor(&execx.MockRunner{}).CommentChar(context.Background()); got != "#" {
+		t.Errorf("unset: got %q, want #", got)
+	}
+	mock := &execx.MockRunner{Results: map[string]execx.Result{key: {Stdout: "auto\n"}}}
+	if got := NewRunnerWithExecutor(mock).CommentChar(context.Background()); got != "auto" {
+		t.Errorf("got %q, want auto", got)
+	}
+}
+
+func TestHead(t *testing.T) {
+	const key = "git\x00rev-parse\x00--verify\x00--quiet\x00HEAD"
+	mock := &execx.MockRunner{Results: map[string]execx.Result{key: {Stdout: "1234\n"}}}
+	if got := NewRunnerWithExecutor(mock).Head(context.Background()); got != "1234" {
+		t.Errorf("got %q, want 1234", got)
+	}
+	unborn := &execx.MockRunner{Errors: map[string]error{key: errors.New("exit status 1")}}
+	if got := NewRunnerWithExecutor(unborn).Head(context.Background()); got != "" {
+		t.Errorf("unborn branch: got %q, want empty", got)
+	}
+}
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// OperationKind identifies a multi-step git operation waiting for a commit.
+type OperationKind string
+
+const (
+	OperationNone       OperationKind = ""
+	OperationMerge      OperationKind = "merge"
+	OperationRevert     OperationKind = "revert"
+	OperationCherryPick OperationKind = "cherry-pick"
+)
+
+// Operation is a merge, revert or cherry-pick in progress.
+type Operation struct {
+	Kind OperationKind
+	Head string // MERGE_HEAD, REVERT_HEAD or CHERRY_PICK_HEAD
+}
+
+// operationHeads maps the pseudo-refs git leaves behind to their operation,
+// in the order they are checked.
+var operationHeads = []struct {
+	ref  string
+	kind OperationKind
+}{
+	{"MERGE_HEAD", OperationMerge},
+	{"REVERT_HEAD", OperationRevert},
+	{"CHERRY_PICK_HEAD", OperationCherryPick},
+}
+
+// InProgressOperation reports the merge, revert or cherry-pick that the next
+// commit concludes. Kind is OperationNone when there is none.
+func (r Runner) InProgressOperation(ctx context.Context) Operation {
+	for _, h := range operationHeads {
+		out, err := r.run(ctx, "git", "rev-parse", "--quiet", "--verify", h.ref)
+		if err == nil && strings.TrimSpace(out) != "" {
+			return Operation{Kind: h.kind, Head: strings.TrimSpace(out)}
+		}
+	}
+	return Operation{}
+}
+
+// MergeMessage returns the message git prepared in MERGE_MSG, or "" if the
+// file does not exist.
+func (r Runner) MergeMessage(ctx context.Context) (string, error) {
+	out, err := r.run(ctx, "git", "rev-parse", "--git-path", "MERGE_MSG")
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse --git-path MERGE_MSG: %w", err)
+	}
+	data, err := os.ReadFile(strings.TrimSpace(out))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", fmt.Errorf("read MERGE_MSG: %w", err)
+	}
+	return string(data), nil
+}
+
+// ParseMergeMessage splits a MERGE_MSG into its header and the files git
+// listed under "Conflicts:" (commented or not).
+func ParseMergeMessage(msg string) (header string, conflicts []string) {
+	inConflicts := false
+	for _, line := range strings.Split(msg, "\n") {
+		trimmed := strings.TrimSpace(strings.TrimPrefix(line, "#"))
+		if trimmed == "Conflicts:" {
+			inConflicts = true
+			continue
+		}
+		if inConflicts {
+			if strings.HasPrefix(strings.TrimPrefix(line, "#"), "\t") && trimmed != "" {
+				conflicts = append(conflicts, trimmed)
+				continue
+			}
+			if trimmed != "" {
+				inConflicts = false
+			}
+		}
+		if header == "" && !strings.HasPrefix(line, "#") && strings.TrimSpace(line) != "" {
+			header = strings.TrimSpace(line)
+		}
+	}
+	return header, conflicts
+}
@@ -0,0 +1,39 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hayatosc/git-cx/internal/execx"
+)
+
+func TestInProgressOperation(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00rev-parse\x00--quiet\x00--verify\x00REVERT_HEAD": {Stdout: "abc123\n"},
+		},
+		Errors: map[string]error{
+			"git\x00rev-parse\x00--quiet\x00--verify\x00MERGE_HEAD": exitError{code: 1},
+		},
+	}
+	op := NewRunnerWithExecutor(mock).InProgressOperation(context.Background())
+	if op.Kind != OperationRevert || op.Head != "abc123" {
+		t.Fatalf("unexpected operation: %+v", op)
+	}
+
+	op = NewRunnerWithExecutor(&execx.MockRunner{}).InProgressOperation(context.Background())
+	if op.Kind != OperationNone {
+		t.Fatalf("expected no operation, got %+v", op)
+	}
+}
+
+func TestParseMergeMessage(t *testing.T) {
+	msg := "Merge branch 'feature/x'\n\n# Conflicts:\n#\tinternal/a.go\n#\tREADME.md\n#\n# It looks like you may be committing a merge.\n"
+	header, conflicts := ParseMergeMessage(msg)
+	if header != "Merge branch 'feature/x'" {
+		t.Fatalf("unexpected header: %q", header)
+	}
+	if len(conflicts) != 2 || conflicts[0] != "internal/a.go" || conflicts[1] != "README.md" {
+		t.Fatalf("unexpected conflicts: %#v", conflicts)
+	}
+}
@@ -0,0 +1,58 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SanitizeBranchName rewrites name so it satisfies git check-ref-format:
+// anything but ASCII letters, digits, "_" and "." becomes "-", and each
+// "/"-separated component is trimmed of leading/trailing "-" and ".".
+func SanitizeBranchName(name string) string {
+	var parts []string
+	for _, part := range strings.Split(name, "/") {
+		var sb strings.Builder
+		lastDash := false
+		for _, r := range part {
+			ok := r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_')
+			if !ok {
+				if !lastDash {
+					sb.WriteByte('-')
+				}
+				lastDash = true
+				continue
+			}
+			sb.WriteRune(r)
+			lastDash = false
+		}
+		part = sb.String()
+		for strings.Contains(part, "..") {
+			part = strings.ReplaceAll(part, "..", ".")
+		}
+		part = strings.TrimSuffix(strings.Trim(part, "-."), ".lock")
+		part = strings.Trim(part, "-.")
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// CheckBranchName validates name with git check-ref-format --branch.
+func (r Runner) CheckBranchName(ctx context.Context, name string) error {
+	if _, err := r.run(ctx, "git", "check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("invalid branch name %q: %w", name, err)
+	}
+	return nil
+}
+
+// SwitchCreate creates branch name from HEAD and switches to it, carrying
+// over staged and unstaged changes.
+func (r Runner) SwitchCreate(ctx context.Context, name string) error {
+	if _, err := r.run(ctx, "git", "switch", "-c", name); err != nil {
+		return fmt.Errorf("git switch -c %s: %w", name, err)
+	}
+	return nil
+}
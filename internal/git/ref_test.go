@@ -0,0 +1,20 @@
+package git
+
+import "testing"
+
+func TestSanitizeBranchName(t *testing.T) {
+	tests := map[string]string{
+		"feat/billing-retry-webhooks":  "feat/billing-retry-webhooks",
+		"feat/Billing Retry Webhooks!": "feat/Billing-Retry-Webhooks",
+		"fix//-ABC-12-nil..check.lock": "fix/ABC-12-nil.check",
+		"feat/~^:?*[\\ weird":          "feat/weird",
+		"chore/.hidden/":               "chore/hidden",
+		"docs/résumé update":           "docs/r-sum-update",
+		"feat/@{upstream}":             "feat/upstream",
+	}
+	for in, want := range tests {
+		if got := SanitizeBranchName(in); got != want {
+			t.Errorf("SanitizeBranchName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// InterpretTrailers appends trailers ("Token: value") to message with
+// git interpret-trailers, so trailer.* configuration (ifExists, where,
+// separators) applies. The message is passed through a temporary file.
+func (r Runner) InterpretTrailers(ctx context.Context, message string, trailers []string) (string, error) {
+	if len(trailers) == 0 {
+		return message, nil
+	}
+	f, err := os.CreateTemp("", "git-cx-msg-*")
+	if err != nil {
+		return "", fmt.Errorf("create message file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	// git only recognises a one-line message as a subject when it ends in a newline.
+	if _, err := f.WriteString(strings.TrimRight(message, "\n") + "\n"); err != nil {
+		f.Close()
+		return "", fmt.Errorf("write message file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("write message file: %w", err)
+	}
+
+	args := []string{"interpret-trailers"}
+	for _, t := range trailers {
+		args = append(args, "--trailer", t)
+	}
+	out, err := r.run(ctx, "git", append(args, f.Name())...)
+	if err != nil {
+		return "", fmt.Errorf("git interpret-trailers: %w", err)
+	}
+	return strings.TrimRight(out, "\n"), nil
+}
+
+// Identity returns the committer as "Name <email>", the form used by
+// Signed-off-by trailers.
+func (r Runner) Identity(ctx context.Context) (string, error) {
+	out, err := r.run(ctx, "git", "var", "GIT_COMMITTER_IDENT")
+	if err != nil {
+		return "", fmt.Errorf("git var GIT_COMMITTER_IDENT: %w", err)
+	}
+	ident := strings.TrimSpace(out)
+	if i := strings.LastIndex(ident, ">"); i >= 0 {
+		ident = ident[:i+1]
+	}
+	return ident, nil
+}
@@ -0,0 +1,46 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hayatosc/git-cx/internal/execx"
+)
+
+func TestInterpretTrailers(t *testing.T) {
+	mock := &execx.MockRunner{}
+	runner := NewRunnerWithExecutor(mock)
+
+	if got, err := runner.InterpretTrailers(context.Background(), "feat: x", nil); err != nil || got != "feat: x" {
+		t.Fatalf("expected message unchanged without trailers, got %q %v", got, err)
+	}
+	if len(mock.Calls) != 0 {
+		t.Fatalf("expected no git call, got %v", mock.Calls)
+	}
+
+	if _, err := runner.InterpretTrailers(context.Background(), "feat: x", []string{"Refs: #1", "Signed-off-by: A <a@example.com>"}); err != nil {
+		t.Fatalf("InterpretTrailers error: %v", err)
+	}
+	args := mock.Calls[0].Args
+	want := []string{"interpret-trailers", "--trailer", "Refs: #1", "--trailer", "Signed-off-by: A <a@example.com>"}
+	if len(args) != len(want)+1 {
+		t.Fatalf("unexpected args: %q", args)
+	}
+	for i, w := range want {
+		if args[i] != w {
+			t.Fatalf("unexpected args: %q", args)
+		}
+	}
+}
+
+func TestIdentity(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00var\x00GIT_COMMITTER_IDENT": {Stdout: "Jane Doe <jane@example.com> 1700000000 +0900\n"},
+		},
+	}
+	got, err := NewRunnerWithExecutor(mock).Identity(context.Background())
+	if err != nil || got != "Jane Doe <jane@example.com>" {
+		t.Fatalf("unexpected identity: %q %v", got, err)
+	}
+}
@@ -0,0 +1,157 @@
+package hook
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Hooks git-cx can install.
+const (
+	PrepareCommitMsg = "prepare-commit-msg" // fills in the message for plain `git commit`
+	CommitMsg        = "commit-msg"         // rejects messages that fail `git cx lint`
+)
+
+// marker identifies hook scripts written by git-cx.
+const marker = "# Installed by git-cx."
+
+// chainedSuffix is appended to a pre-existing hook that git-cx runs before itself.
+const chainedSuffix = ".git-cx-chained"
+
+// ErrForeignHook is returned when a hook exists that was not installed by git-cx.
+var ErrForeignHook = errors.New("hook was not installed by git-cx")
+
+// Status describes the installation state of a hook.
+type Status struct {
+	Name      string
+	Path      string
+	Installed bool   // the hook file was written by git-cx
+	Foreign   bool   // a hook file exists that was not written by git-cx
+	Chained   string // path of the pre-existing hook run before git-cx, if any
+}
+
+// Names returns the hooks git-cx can install.
+func Names() []string {
+	return []string{PrepareCommitMsg, CommitMsg}
+}
+
+// Script returns the shell script installed for the named hook.
+func Script(name string) (string, error) {
+	body, ok := scripts[name]
+	if !ok {
+		return "", fmt.Errorf("unsupported hook %q (supported: %s)", name, strings.Join(Names(), ", "))
+	}
+	var sb strings.Builder
+	sb.WriteString("#!/bin/sh\n")
+	sb.WriteString(marker + " Remove with `git cx hook uninstall " + name + "`.\n")
+	sb.WriteString("chained=\"$(dirname \"$0\")/" + name + chainedSuffix + "\"\n")
+	sb.WriteString("if [ -x \"$chained\" ]; then\n")
+	sb.WriteString("\t\"$chained\" \"$@\" || exit $?\n")
+	sb.WriteString("fi\n")
+	sb.WriteString(body)
+	return sb.String(), nil
+}
+
+var scripts = map[string]string{
+	// Only plain `git commit` gets a generated message; -m/-F, merges, squashes
+	// and amends already carry one. Without a terminal (IDEs) git-cx picks the
+	// first candidate non-interactively. Failures never block the commit.
+	PrepareCommitMsg: `case "$2" in
+	""|template) ;;
+	*) exit 0 ;;
+esac
+if ( : < /dev/tty ) 2>/dev/null; then
+	git cx --message-file "$1" < /dev/tty > /dev/tty || true
+else
+	git cx --message-file "$1" || true
+fi
+exit 0
+`,
+	CommitMsg: `exec git cx lint --file "$1"
+`,
+}
+
+// Inspect reports the state of the named hook in dir.
+func Inspect(dir, name string) (Status, error) {
+	path := filepath.Join(dir, name)
+	st := Status{Name: name, Path: path}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return st, fmt.Errorf("read hook %s: %w", path, err)
+	case isManaged(string(data)):
+		st.Installed = true
+	default:
+		st.Foreign = true
+	}
+	if _, err := os.Stat(path + chainedSuffix); err == nil {
+		st.Chained = path + chainedSuffix
+	}
+	return st, nil
+}
+
+// Install writes the named hook into dir. An existing hook that was not
+// written by git-cx is preserved and chained so it still runs first.
+func Install(dir, name string) (Status, error) {
+	script, err := Script(name)
+	if err != nil {
+		return Status{}, err
+	}
+	st, err := Inspect(dir, name)
+	if err != nil {
+		return st, err
+	}
+	if st.Foreign {
+		if st.Chained != "" {
+			return st, fmt.Errorf("%s: %w and %s already exists", st.Path, ErrForeignHook, st.Chained)
+		}
+		if err := os.Rename(st.Path, st.Path+chainedSuffix); err != nil {
+			return st, fmt.Errorf("chain existing hook: %w", err)
+		}
+		st.Chained = st.Path + chainedSuffix
+		st.Foreign = false
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return st, fmt.Errorf("create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(st.Path, []byte(script), 0o755); err != nil {
+		return st, fmt.Errorf("write hook %s: %w", st.Path, err)
+	}
+	st.Installed = true
+	return st, nil
+}
+
+// Uninstall removes the named hook from dir and restores a chained hook.
+func Uninstall(dir, name string) (Status, error) {
+	if _, err := Script(name); err != nil {
+		return Status{}, err
+	}
+	st, err := Inspect(dir, name)
+	if err != nil {
+		return st, err
+	}
+	if st.Foreign {
+		return st, fmt.Errorf("%s: %w", st.Path, ErrForeignHook)
+	}
+	if st.Installed {
+		if err := os.Remove(st.Path); err != nil {
+			return st, fmt.Errorf("remove hook %s: %w", st.Path, err)
+		}
+		st.Installed = false
+	}
+	if st.Chained != "" {
+		if err := os.Rename(st.Chained, st.Path); err != nil {
+			return st, fmt.Errorf("restore chained hook: %w", err)
+		}
+		st.Chained = ""
+		st.Foreign = true
+	}
+	return st, nil
+}
+
+func isManaged(script string) bool {
+	return strings.Contains(script, marker)
+}
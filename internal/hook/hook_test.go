@@ -0,0 +1,111 @@
+package hook
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstall_WritesExecutableScript(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hooks")
+	st, err := Install(dir, PrepareCommitMsg)
+	if err != nil {
+		t.Fatalf("Install error: %v", err)
+	}
+	if !st.Installed || st.Chained != "" {
+		t.Fatalf("unexpected status: %+v", st)
+	}
+	info, err := os.Stat(filepath.Join(dir, PrepareCommitMsg))
+	if err != nil {
+		t.Fatalf("hook not written: %v", err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Fatalf("hook is not executable: %v", info.Mode())
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, PrepareCommitMsg))
+	if !strings.Contains(string(data), `git cx --message-file "$1"`) {
+		t.Fatalf("unexpected script:\n%s", data)
+	}
+}
+
+func TestInstall_ChainsExistingHook(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, PrepareCommitMsg)
+	if err := os.WriteFile(existing, []byte("#!/bin/sh\necho mine\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := Install(dir, PrepareCommitMsg)
+	if err != nil {
+		t.Fatalf("Install error: %v", err)
+	}
+	if st.Chained != existing+chainedSuffix {
+		t.Fatalf("expected chained hook, got %+v", st)
+	}
+	data, _ := os.ReadFile(st.Chained)
+	if string(data) != "#!/bin/sh\necho mine\n" {
+		t.Fatalf("chained hook content changed: %q", data)
+	}
+
+	// Reinstalling must not chain git-cx onto itself.
+	st, err = Install(dir, PrepareCommitMsg)
+	if err != nil {
+		t.Fatalf("reinstall error: %v", err)
+	}
+	data, _ = os.ReadFile(st.Chained)
+	if string(data) != "#!/bin/sh\necho mine\n" {
+		t.Fatalf("chained hook overwritten on reinstall: %q", data)
+	}
+}
+
+func TestUninstall_RestoresChainedHook(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, PrepareCommitMsg)
+	if err := os.WriteFile(existing, []byte("#!/bin/sh\necho mine\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Install(dir, PrepareCommitMsg); err != nil {
+		t.Fatalf("Install error: %v", err)
+	}
+
+	st, err := Uninstall(dir, PrepareCommitMsg)
+	if err != nil {
+		t.Fatalf("Uninstall error: %v", err)
+	}
+	if st.Installed || st.Chained != "" {
+		t.Fatalf("unexpected status: %+v", st)
+	}
+	data, _ := os.ReadFile(existing)
+	if string(data) != "#!/bin/sh\necho mine\n" {
+		t.Fatalf("original hook not restored: %q", data)
+	}
+}
+
+func TestUninstall_RefusesForeignHook(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, PrepareCommitMsg), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Uninstall(dir, PrepareCommitMsg)
+	if !errors.Is(err, ErrForeignHook) {
+		t.Fatalf("expected ErrForeignHook, got %v", err)
+	}
+}
+
+func TestScript_UnsupportedHook(t *testing.T) {
+	if _, err := Script("pre-push"); err == nil {
+		t.Fatal("expected error for unsupported hook")
+	}
+}
+
+func TestScript_CommitMsgRunsLint(t *testing.T) {
+	script, err := Script(CommitMsg)
+	if err != nil {
+		t.Fatalf("Script error: %v", err)
+	}
+	if !strings.Contains(script, `git cx lint --file "$1"`) {
+		t.Fatalf("unexpected script:\n%s", script)
+	}
+}
@@ -0,0 +1,502 @@
+// Package plain runs the commit flow with line-oriented prompts: numbered
+// choices read from stdin and text printed top to bottom, without the alt
+// screen or cursor movement. It is meant for screen readers, dumb terminals
+// and shells such as Emacs shell-mode, where the TUI cannot be used.
+package plain
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hayatosc/git-cx/internal/app"
+	"github.com/hayatosc/git-cx/internal/commit"
+	"github.com/hayatosc/git-cx/internal/git"
+)
+
+// ErrAborted is returned by Run when the user aborts or stdin ends.
+var ErrAborted = errors.New("aborted")
+
+// Prompter asks for the commit message line by line.
+type Prompter struct {
+	service   *app.CommitService
+	diff      string
+	stat      string
+	dryRun    bool
+	operation git.Operation
+
+	in  *bufio.Reader
+	out io.Writer
+
+	commitType   string
+	scope        string
+	subject      string
+	emoji        string
+	body         string
+	trailers     []commit.Footer
+	breaking     bool
+	breakingNote string
+}
+
+// New creates a Prompter reading answers from in and writing to out.
+func New(service *app.CommitService, diff, stat string, dryRun bool, in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{
+		service: service,
+		diff:    diff,
+		stat:    stat,
+		dryRun:  dryRun,
+		in:      bufio.NewReader(in),
+		out:     out,
+	}
+}
+
+// WithOperation makes the prompter conclude op: the message is derived from
+// the merge, revert or cherry-pick instead of picking a type and candidates.
+func (p *Prompter) WithOperation(op git.Operation) *Prompter {
+	p.operation = op
+	return p
+}
+
+// Run asks for the message and commits it. It returns ErrAborted when the
+// user aborts.
+func (p *Prompter) Run(ctx context.Context) error {
+	p.trailers = p.service.DefaultTrailers(ctx)
+	if p.operation.Kind != git.OperationNone {
+		if err := p.operationMessage(ctx); err != nil {
+			return err
+		}
+	} else {
+		if err := p.header(ctx); err != nil {
+			return err
+		}
+		if err := p.details(ctx); err != nil {
+			return err
+		}
+	}
+	if err := p.editTrailers(); err != nil {
+		return err
+	}
+	return p.confirm(ctx)
+}
+
+// operationMessage takes the message of an in-progress merge, revert or
+// cherry-pick, letting the user adjust the body.
+func (p *Prompter) operationMessage(ctx context.Context) error {
+	p.printf("Generating %s commit message...\n", p.operation.Kind)
+	subject, body, err := p.service.OperationMessage(ctx, p.operation, p.stat)
+	if err != nil {
+		p.printf("AI error: %v\n", err)
+	}
+	if subject == "" {
+		if subject, err = p.ask("Commit message: "); err != nil {
+			return err
+		}
+	}
+	p.subject = subject
+	p.body, err = p.readText("Commit body", body)
+	return err
+}
+
+// header picks the type, scope and subject.
+func (p *Prompter) header(ctx context.Context) error {
+	types := []string{commit.AutoType + " - " + commit.AutoTypeDescription}
+	names := []string{commit.AutoType}
+	for _, t := range p.service.Types() {
+		types = append(types, t.Name+" - "+t.Description)
+		names = append(names, t.Name)
+	}
+	i, err := p.choose("Select commit type", types, 0)
+	if err != nil {
+		return err
+	}
+	p.commitType = names[i]
+	if p.scope, err = p.ask("Scope (optional): "); err != nil {
+		return err
+	}
+
+	var hint string
+	var rejected []string
+	for {
+		p.printf("Generating commit messages...\n")
+		candidates, err := p.service.RegenerateCandidates(ctx, p.diff, p.stat, p.typeForMessage(), p.scope, hint, rejected)
+		if err != nil || len(candidates) == 0 {
+			if err != nil {
+				p.printf("AI error: %v\n", err)
+			}
+			return p.inputSubject()
+		}
+		options := make([]string, 0, len(candidates)+3)
+		for _, c := range candidates {
+			option := c.Header
+			if c.Rationale != "" {
+				option += "\n     " + c.Rationale
+			}
+			options = append(options, option)
+		}
+		options = append(options, "[Manual entry]", "[Regenerate]", "[Regenerate with hint…]")
+		i, err := p.choose("Select commit message", options, 0)
+		if err != nil {
+			return err
+		}
+		switch {
+		case i < len(candidates):
+			p.subject = candidates[i].Header
+			if h, err := commit.ParseHeader(p.subject); err == nil {
+				p.breaking = h.Breaking
+			}
+			return p.selectGitmoji()
+		case options[i] == "[Manual entry]":
+			return p.inputSubject()
+		case options[i] == "[Regenerate]":
+			hint, rejected = "", nil
+		default:
+			if hint, err = p.ask("How should the new suggestions differ? "); err != nil {
+				return err
+			}
+			for _, c := range candidates {
+				rejected = append(rejected, c.Header)
+			}
+		}
+	}
+}
+
+func (p *Prompter) inputSubject() error {
+	prompt := "Commit subject: "
+	if p.commitType == commit.AutoType {
+		prompt = "Commit subject (Conventional header): "
+	}
+	for {
+		subject, err := p.ask(prompt)
+		if err != nil {
+			return err
+		}
+		if subject != "" {
+			p.subject = subject
+			return p.selectGitmoji()
+		}
+	}
+}
+
+// selectGitmoji asks for the emoji when cx.commit.gitmoji is set, offering
+// the one the AI or the type suggests.
+func (p *Prompter) selectGitmoji() error {
+	if !p.service.Gitmoji() {
+		return nil
+	}
+	suggested, subject := commit.CutEmoji(p.subject)
+	p.subject = subject
+	if suggested == "" {
+		suggested = p.service.TypeEmoji(p.typeForMessage())
+	}
+	want, ok := commit.LookupGitmoji(suggested)
+	prompt := "Gitmoji (emoji or shortcode, empty for none): "
+	if ok {
+		prompt = fmt.Sprintf("Gitmoji (emoji or shortcode, Enter for %s :%s:, - for none): ", want.Emoji, want.Code)
+	}
+	for {
+		answer, err := p.ask(prompt)
+		if err != nil {
+			return err
+		}
+		switch answer {
+		case "":
+			if ok {
+				p.emoji = want.Emoji
+			}
+			return nil
+		case "-":
+			return nil
+		}
+		if g, found := commit.LookupGitmoji(answer); found {
+			p.emoji = g.Emoji
+			return nil
+		}
+		p.printf("Unknown gitmoji %q.\n", answer)
+	}
+}
+
+// details asks for the body, generated by the AI or typed in.
+func (p *Prompter) details(ctx context.Context) error {
+	options := []string{
+		"[Skip] - Skip body and footer",
+		"[Generate with AI] - Generate body/footer with AI",
+		"[Manual entry] - Enter body/footer manually",
+	}
+	i, err := p.choose("Select detail input", options, 1)
+	if err != nil {
+		return err
+	}
+	var body string
+	switch i {
+	case 0:
+		return nil
+	case 1:
+		p.printf("Generating commit details...\n")
+		var footer string
+		body, footer, err = p.service.GenerateDetails(ctx, p.diff, p.stat, p.typeForMessage(), p.scope, p.subject)
+		if err != nil {
+			p.printf("AI error: %v\n", err)
+			break
+		}
+		footers, ok := commit.ParseFooters(footer)
+		if !ok {
+			// Not a trailer block; keep the text in the body rather than drop it.
+			body = strings.TrimSpace(body + "\n\n" + footer)
+		}
+		for _, f := range footers {
+			switch {
+			case f.IsBreaking():
+				p.breaking = true
+				p.breakingNote = strings.TrimSpace(f.Value)
+			case !slices.Contains(p.trailers, f):
+				p.trailers = append(p.trailers, f)
+			}
+		}
+	}
+	p.body, err = p.readText("Commit body", body)
+	return err
+}
+
+// editTrailers lists the trailers and takes additions ("Token: value") and
+// removals (the trailer's number) until an empty line.
+func (p *Prompter) editTrailers() error {
+	for {
+		p.printf("\nTrailers:\n")
+		if len(p.trailers) == 0 {
+			p.printf("  (none)\n")
+		}
+		for i, t := range p.trailers {
+			p.printf("  %d) %s%s%s\n", i+1, t.Token, t.Separator, t.Value)
+		}
+		answer, err := p.ask("Add \"Token: value\", remove by number, Enter to continue: ")
+		if err != nil {
+			return err
+		}
+		if answer == "" {
+			return nil
+		}
+		if n, err := strconv.Atoi(answer); err == nil {
+			if n < 1 || n > len(p.trailers) {
+				p.printf("No trailer %d.\n", n)
+				continue
+			}
+			p.trailers = slices.Delete(slices.Clone(p.trailers), n-1, n)
+			continue
+		}
+		footers, ok := commit.ParseFooters(answer)
+		if !ok || len(footers) != 1 {
+			p.printf("Error: trailer must look like \"Token: value\" or \"Token #value\"\n")
+			continue
+		}
+		if footers[0].IsBreaking() {
+			// Breaking changes have their own step so flag and footer agree.
+			p.breaking = true
+			p.breakingNote = strings.TrimSpace(footers[0].Value)
+			continue
+		}
+		p.trailers = append(slices.Clone(p.trailers), footers[0])
+	}
+}
+
+// confirm shows the final message and commits it, offering to retry when
+// git commit fails.
+func (p *Prompter) confirm(ctx context.Context) error {
+	for {
+		message, err := p.service.FinalMessage(ctx, p.conventionalCommit(), p.trailers)
+		p.printf("\nCommit message:\n\n%s\n", indent(message))
+		if err != nil {
+			p.printf("Trailer error (added as plain footers): %v\n", err)
+		}
+		verb := "commit"
+		if p.dryRun {
+			verb = "preview"
+		}
+		answer, err := p.ask(fmt.Sprintf("y to %s, b to toggle breaking change, n to abort [y]: ", verb))
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(answer) {
+		case "", "y", "yes":
+			if p.dryRun {
+				p.printf("[DRY RUN] Not committed.\n")
+				return nil
+			}
+			return p.commit(ctx, message)
+		case "b":
+			if err := p.toggleBreaking(ctx); err != nil {
+				return err
+			}
+		case "n", "no", "q":
+			return ErrAborted
+		}
+	}
+}
+
+func (p *Prompter) toggleBreaking(ctx context.Context) error {
+	if p.breaking {
+		p.breaking = false
+		p.breakingNote = ""
+		p.subject = commit.MarkBreaking(p.subject, false)
+		return nil
+	}
+	p.breaking = true
+	note := p.breakingNote
+	if note == "" {
+		p.printf("Looking for breaking changes...\n")
+		var err error
+		if note, err = p.service.GenerateBreakingNote(ctx, p.diff, p.stat, p.typeForMessage(), p.scope, p.subject); err != nil {
+			p.printf("AI error: %v\n", err)
+		}
+	}
+	var err error
+	p.breakingNote, err = p.readText("Describe the breaking change (BREAKING CHANGE footer)", note)
+	return err
+}
+
+func (p *Prompter) commit(ctx context.Context, message string) error {
+	for {
+		out, err := p.service.Commit(ctx, message)
+		if err == nil {
+			if out = strings.TrimSpace(out); out != "" {
+				p.printf("%s\n", out)
+			}
+			if p.service.MessageFile() != "" {
+				p.printf("Commit message prepared.\n")
+			} else {
+				p.printf("Committed successfully!\n")
+			}
+			return nil
+		}
+		if out == "" {
+			out = err.Error()
+		}
+		p.printf("git commit failed:\n%s\n", indent(out))
+		prompt := "r to retry, v to retry with --no-verify, q to quit: "
+		if p.service.CommitOptions().NoVerify {
+			prompt = "r to retry, q to quit: "
+		}
+		answer, err := p.ask(prompt)
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(answer) {
+		case "r":
+		case "v":
+			opts := p.service.CommitOptions()
+			opts.NoVerify = true
+			p.service.SetCommitOptions(opts)
+		default:
+			return ErrAborted
+		}
+	}
+}
+
+// choose lists options numbered from 1 and returns the index of the one
+// picked; an empty answer picks def.
+func (p *Prompter) choose(title string, options []string, def int) (int, error) {
+	p.printf("\n%s:\n", title)
+	for i, o := range options {
+		p.printf("  %d) %s\n", i+1, o)
+	}
+	for {
+		answer, err := p.ask(fmt.Sprintf("Choice [%d]: ", def+1))
+		if err != nil {
+			return 0, err
+		}
+		if answer == "" {
+			return def, nil
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		p.printf("Enter a number from 1 to %d.\n", len(options))
+	}
+}
+
+// ask prints prompt and returns the next line of input, trimmed.
+func (p *Prompter) ask(prompt string) (string, error) {
+	p.printf("%s", prompt)
+	line, err := p.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		p.printf("\n")
+		return "", ErrAborted
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// readText reads several lines ending with a line holding only ".". When
+// current is set it is shown first and an empty first line keeps it; "-"
+// clears it.
+func (p *Prompter) readText(title, current string) (string, error) {
+	p.printf("\n%s", title)
+	if current != "" {
+		p.printf(":\n\n%s\n\nEnter to keep it, - to clear it, or type a replacement ending with a line holding only \".\":\n", indent(current))
+	} else {
+		p.printf(" (optional), ending with a line holding only \".\" (Enter to skip):\n")
+	}
+	var lines []string
+	for {
+		line, err := p.in.ReadString('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+			return "", ErrAborted
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if len(lines) == 0 {
+			switch strings.TrimSpace(line) {
+			case "":
+				return current, nil
+			case "-":
+				return "", nil
+			}
+		}
+		if line == "." {
+			return strings.TrimSpace(strings.Join(lines, "\n")), nil
+		}
+		lines = append(lines, line)
+	}
+}
+
+func (p *Prompter) printf(format string, args ...any) {
+	fmt.Fprintf(p.out, format, args...)
+}
+
+func (p *Prompter) typeForMessage() string {
+	if p.commitType == commit.AutoType {
+		return ""
+	}
+	return p.commitType
+}
+
+// conventionalCommit assembles the message being built, adding the
+// BREAKING CHANGE footer when a note was given.
+func (p *Prompter) conventionalCommit() *commit.ConventionalCommit {
+	c := &commit.ConventionalCommit{
+		Emoji:    p.emoji,
+		Type:     p.typeForMessage(),
+		Scope:    p.scope,
+		Breaking: p.breaking,
+		Subject:  p.subject,
+		Body:     p.body,
+	}
+	if p.breaking && p.breakingNote != "" {
+		c.Footers = []commit.Footer{{Token: "BREAKING CHANGE", Separator: ": ", Value: p.breakingNote}}
+	}
+	return c
+}
+
+// indent prefixes every line of s with two spaces so that it stands apart
+// from the prompts.
+func indent(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = "  " + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
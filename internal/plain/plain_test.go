@@ -0,0 +1,142 @@
+package plain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hayatosc/git-cx/internal/ai"
+	"github.com/hayatosc/git-cx/internal/app"
+	"github.com/hayatosc/git-cx/internal/config"
+	"github.com/hayatosc/git-cx/internal/execx"
+	"github.com/hayatosc/git-cx/internal/git"
+)
+
+var commitKey = "git\x00commit\x00-F\x00-"
+
+func run(t *testing.T, provider *ai.MockProvider, mock *execx.MockRunner, input string) (string, error) {
+	t.Helper()
+	service := app.NewCommitService(&config.Config{Candidates: 2}, provider, git.NewRunnerWithExecutor(mock))
+	var out strings.Builder
+	err := New(service, "diff", "stat", false, strings.NewReader(input), &out).Run(context.Background())
+	return out.String(), err
+}
+
+// committed returns the message passed to git commit.
+func committed(t *testing.T, mock *execx.MockRunner) string {
+	t.Helper()
+	var message string
+	for _, c := range mock.Calls {
+		if c.Name == "git" && len(c.Args) > 0 && c.Args[0] == "commit" {
+			message = c.Input
+		}
+	}
+	if message == "" {
+		t.Fatalf("git commit not run, calls: %#v", mock.Calls)
+	}
+	return message
+}
+
+func TestRun_candidate(t *testing.T) {
+	provider := &ai.MockProvider{Candidates: []string{"add parser", "add lexer // feat because a new package is added"}}
+	mock := &execx.MockRunner{}
+	// type 2 (first after auto), scope, candidate 2, skip details, no trailers, commit.
+	out, err := run(t, provider, mock, "2\ncore\n2\n1\n\n\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, out)
+	}
+	if got := committed(t, mock); !strings.HasSuffix(strings.TrimSpace(got), "(core): add lexer") {
+		t.Fatalf("unexpected message %q", got)
+	}
+	for _, want := range []string{"  1) auto - ", "  2) add lexer\n     feat because a new package is added", "  3) [Manual entry]", "Choice [1]: ", "Committed successfully!"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\x1b") {
+		t.Errorf("expected no escape sequences, got %q", out)
+	}
+}
+
+func TestRun_hintAndBody(t *testing.T) {
+	provider := &ai.MockProvider{Candidates: []string{"feat: add parser"}}
+	mock := &execx.MockRunner{}
+	input := strings.Join([]string{
+		"",        // auto
+		"",        // no scope
+		"4",       // [Regenerate with hint…]
+		"shorter", // hint
+		"1",       // candidate
+		"3",       // [Manual entry]
+		"First line.",
+		"Second line.",
+		".",
+		"oops",      // not a trailer
+		"Refs: #12", // added
+		"1",         // and removed again
+		"",
+		"y",
+	}, "\n") + "\n"
+	out, err := run(t, provider, mock, input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, out)
+	}
+	if req := provider.LastReq; req.Hint != "shorter" || len(req.Rejected) != 1 {
+		t.Fatalf("expected the hint and rejected candidate to be passed on, got %#v", req)
+	}
+	if !strings.Contains(out, "trailer must look like") || !strings.Contains(out, "  1) Refs: #12") {
+		t.Fatalf("expected the trailer to be checked and listed:\n%s", out)
+	}
+	want := "feat: add parser\n\nFirst line.\nSecond line."
+	if got := strings.TrimSpace(committed(t, mock)); got != want {
+		t.Fatalf("unexpected message:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRun_invalidChoiceAsksAgain(t *testing.T) {
+	provider := &ai.MockProvider{Err: errors.New("offline")}
+	mock := &execx.MockRunner{}
+	out, err := run(t, provider, mock, "99\n1\n\nfix: typo\n1\n\n\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Enter a number from 1 to") || !strings.Contains(out, "AI error: offline") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+	if got := strings.TrimSpace(committed(t, mock)); got != "fix: typo" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
+
+func TestRun_abort(t *testing.T) {
+	provider := &ai.MockProvider{Candidates: []string{"feat: add parser"}}
+	mock := &execx.MockRunner{}
+	if _, err := run(t, provider, mock, "\n\n1\n1\n\nn\n"); !errors.Is(err, ErrAborted) {
+		t.Fatalf("expected ErrAborted, got %v", err)
+	}
+	if _, err := run(t, provider, &execx.MockRunner{}, "\n"); !errors.Is(err, ErrAborted) {
+		t.Fatalf("expected ErrAborted at the end of input, got %v", err)
+	}
+	for _, c := range mock.Calls {
+		if c.Name == "git" && len(c.Args) > 0 && c.Args[0] == "commit" {
+			t.Fatalf("expected no commit after aborting")
+		}
+	}
+}
+
+func TestRun_retryWithNoVerify(t *testing.T) {
+	provider := &ai.MockProvider{Candidates: []string{"feat: add parser"}}
+	mock := &execx.MockRunner{Errors: map[string]error{commitKey: errors.New("hook failed")}}
+	out, err := run(t, provider, mock, "\n\n1\n1\n\n\nv\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "git commit failed") || !strings.Contains(out, "hook failed") {
+		t.Fatalf("expected the failure to be shown:\n%s", out)
+	}
+	last := mock.Calls[len(mock.Calls)-1]
+	if !strings.Contains(strings.Join(last.Args, " "), "--no-verify") {
+		t.Fatalf("expected a retry with --no-verify, got %v", last.Args)
+	}
+}
@@ -0,0 +1,181 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Bump is the kind of version increment a set of changes requires.
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	}
+	return "none"
+}
+
+// Version is a semantic version, optionally written with a "v" prefix.
+type Version struct {
+	Prefix     string
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+var versionPattern = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// Parse parses s as a semantic version. Build metadata is discarded.
+func Parse(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Version{}, fmt.Errorf("invalid semantic version %q", s)
+	}
+	major, _ := strconv.Atoi(m[2])
+	minor, _ := strconv.Atoi(m[3])
+	patch, _ := strconv.Atoi(m[4])
+	return Version{Prefix: m[1], Major: major, Minor: minor, Patch: patch, Prerelease: m[5]}, nil
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Core returns v without its pre-release part.
+func (v Version) Core() Version {
+	v.Prerelease = ""
+	return v
+}
+
+// Bump returns the next release version for the given increment.
+func (v Version) Bump(b Bump) Version {
+	next := v.Core()
+	switch b {
+	case BumpMajor:
+		next.Major++
+		next.Minor, next.Patch = 0, 0
+	case BumpMinor:
+		next.Minor++
+		next.Patch = 0
+	case BumpPatch:
+		next.Patch++
+	}
+	return next
+}
+
+// Compare returns -1, 0 or 1 following semver precedence rules.
+func Compare(a, b Version) int {
+	for _, d := range [][2]int{{a.Major, b.Major}, {a.Minor, b.Minor}, {a.Patch, b.Patch}} {
+		if d[0] != d[1] {
+			return cmpInt(d[0], d[1])
+		}
+	}
+	switch {
+	case a.Prerelease == b.Prerelease:
+		return 0
+	case a.Prerelease == "":
+		return 1
+	case b.Prerelease == "":
+		return -1
+	}
+	ap := strings.Split(a.Prerelease, ".")
+	bp := strings.Split(b.Prerelease, ".")
+	for i := 0; i < len(ap) && i < len(bp); i++ {
+		if c := comparePrereleaseID(ap[i], bp[i]); c != 0 {
+			return c
+		}
+	}
+	return cmpInt(len(ap), len(bp))
+}
+
+func comparePrereleaseID(a, b string) int {
+	an, aErr := strconv.Atoi(a)
+	bn, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return cmpInt(an, bn)
+	case aErr == nil:
+		return -1 // numeric identifiers sort before alphanumeric ones
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Latest returns the highest stable version among tags and the pre-releases
+// newer than it. Tags that are not semantic versions are ignored. ok is
+// false when no stable version was found.
+func Latest(tags []string) (stable Version, pres []Version, ok bool) {
+	var all []Version
+	for _, tag := range tags {
+		v, err := Parse(tag)
+		if err != nil {
+			continue
+		}
+		if v.Prerelease != "" {
+			all = append(all, v)
+			continue
+		}
+		if !ok || Compare(v, stable) > 0 {
+			stable, ok = v, true
+		}
+	}
+	for _, v := range all {
+		if !ok || Compare(v, stable) > 0 {
+			pres = append(pres, v)
+		}
+	}
+	return stable, pres, ok
+}
+
+// Next returns the release that follows stable for a change of size b. With
+// a channel it returns a pre-release such as 1.3.0-rc.1, numbered after the
+// highest of pres for the same version and channel.
+func Next(stable Version, b Bump, channel string, pres []Version) Version {
+	next := stable.Bump(b)
+	if channel == "" {
+		return next
+	}
+	n := 1
+	for _, pre := range pres {
+		if Compare(pre.Core(), next.Core()) != 0 {
+			continue
+		}
+		if ch, num, found := strings.Cut(pre.Prerelease, "."); found && ch == channel {
+			if v, err := strconv.Atoi(num); err == nil && v >= n {
+				n = v + 1
+			}
+		}
+	}
+	next.Prerelease = fmt.Sprintf("%s.%d", channel, n)
+	return next
+}
@@ -0,0 +1,76 @@
+package semver
+
+import "testing"
+
+func TestParseAndString(t *testing.T) {
+	for _, in := range []string{"v1.2.3", "0.2.0", "v2.0.0-rc.1"} {
+		v, err := Parse(in)
+		if err != nil {
+			t.Fatalf("Parse(%q) error: %v", in, err)
+		}
+		if v.String() != in {
+			t.Fatalf("round trip %q -> %q", in, v.String())
+		}
+	}
+	for _, in := range []string{"1.2", "v01.2.3", "release-1"} {
+		if _, err := Parse(in); err == nil {
+			t.Fatalf("Parse(%q) should fail", in)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	order := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.1.0"}
+	for i := 1; i < len(order); i++ {
+		a, _ := Parse(order[i-1])
+		b, _ := Parse(order[i])
+		if Compare(a, b) != -1 || Compare(b, a) != 1 {
+			t.Fatalf("expected %s < %s", order[i-1], order[i])
+		}
+	}
+}
+
+func TestLatest(t *testing.T) {
+	stable, pres, ok := Latest([]string{"v1.1.0", "v1.2.0", "v1.3.0-rc.1", "v1.0.5", "nightly"})
+	if !ok || stable.String() != "v1.2.0" || len(pres) != 1 || pres[0].String() != "v1.3.0-rc.1" {
+		t.Fatalf("unexpected latest: %v %v %v", stable, pres, ok)
+	}
+	_, pres, _ = Latest([]string{"v1.2.0", "v1.2.0-rc.3"})
+	if len(pres) != 0 {
+		t.Fatalf("older pre-release should be ignored, got %v", pres)
+	}
+}
+
+func TestNext(t *testing.T) {
+	stable, _ := Parse("v1.2.3")
+	rc2, _ := Parse("v1.3.0-rc.2")
+	tests := []struct {
+		bump    Bump
+		channel string
+		pres    []Version
+		want    string
+	}{
+		{BumpPatch, "", nil, "v1.2.4"},
+		{BumpMinor, "", nil, "v1.3.0"},
+		{BumpMajor, "", nil, "v2.0.0"},
+		{BumpMinor, "rc", nil, "v1.3.0-rc.1"},
+		{BumpMinor, "rc", []Version{rc2}, "v1.3.0-rc.3"},
+		{BumpMinor, "beta", []Version{rc2}, "v1.3.0-beta.1"},
+		{BumpMajor, "rc", []Version{rc2}, "v2.0.0-rc.1"},
+	}
+	for _, tt := range tests {
+		if got := Next(stable, tt.bump, tt.channel, tt.pres).String(); got != tt.want {
+			t.Errorf("Next(%s, %s, %q) = %s, want %s", stable, tt.bump, tt.channel, got, tt.want)
+		}
+	}
+}
+
+func TestNext_twoChannels(t *testing.T) {
+	stable, pres, _ := Latest([]string{"v1.2.3", "v1.3.0-beta.1", "v1.3.0-beta.2", "v1.3.0-rc.1"})
+	if got := Next(stable, BumpMinor, "beta", pres).String(); got != "v1.3.0-beta.3" {
+		t.Errorf("beta: got %s, want v1.3.0-beta.3", got)
+	}
+	if got := Next(stable, BumpMinor, "rc", pres).String(); got != "v1.3.0-rc.2" {
+		t.Errorf("rc: got %s, want v1.3.0-rc.2", got)
+	}
+}
@@ -0,0 +1,363 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// diffFile is the part of a diff that touches one file.
+type diffFile struct {
+	name    string
+	lines   []string
+	added   int
+	deleted int
+}
+
+// diffMatch is a line that contains the search query.
+type diffMatch struct {
+	file, line int
+}
+
+// diffView is a scrollable, searchable diff pane with a file list. It is
+// opened over the list and confirm screens so the diff can be checked before
+// accepting what the AI wrote about it.
+type diffView struct {
+	files    []diffFile
+	file     int
+	listTop  int // first file shown in the file list
+	viewport viewport.Model
+
+	search    textinput.Model
+	searching bool
+	query     string
+	matches   []diffMatch
+	match     int
+
+	width  int
+	height int
+
+	keys keyMap
+}
+
+func newDiffView(diff string, keys keyMap) diffView {
+	search := textinput.New()
+	search.Prompt = "/"
+	vp := viewport.New(0, 0)
+	vp.KeyMap.Up = keys.Up
+	vp.KeyMap.Down = keys.Down
+	v := diffView{files: splitDiff(diff), viewport: vp, search: search, match: -1, keys: keys}
+	v.render()
+	return v
+}
+
+// splitDiff splits a git diff into files at each "diff --git" line. Text
+// before the first one, or a diff without any, becomes a single entry.
+func splitDiff(diff string) []diffFile {
+	var files []diffFile
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		if strings.HasPrefix(line, "diff --git ") || len(files) == 0 {
+			files = append(files, diffFile{name: diffFileName(line)})
+		}
+		f := &files[len(files)-1]
+		f.lines = append(f.lines, line)
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			f.added++
+		case strings.HasPrefix(line, "-"):
+			f.deleted++
+		}
+	}
+	if len(files) == 1 && strings.TrimSpace(diff) == "" {
+		files[0] = diffFile{name: "(no changes)"}
+	}
+	return files
+}
+
+// diffFileName returns the new path from a "diff --git a/x b/y" line.
+func diffFileName(header string) string {
+	rest, ok := strings.CutPrefix(header, "diff --git ")
+	if !ok {
+		return "(diff)"
+	}
+	if i := strings.LastIndex(rest, " b/"); i >= 0 {
+		return rest[i+len(" b/"):]
+	}
+	return rest
+}
+
+// SetSize fits the pane, title and help line into width x height.
+func (v *diffView) SetSize(width, height int) {
+	v.width, v.height = width, height
+	v.viewport.Width = max(width-v.listWidth()-1, 0)
+	v.viewport.Height = max(height-3, 1)
+	v.scrollList()
+	v.render()
+}
+
+func (v diffView) listWidth() int {
+	return min(32, v.width/3)
+}
+
+// Update handles a key; closed is true when the viewer should be dismissed.
+func (v diffView) Update(msg tea.KeyMsg) (diffView, tea.Cmd, bool) {
+	if v.searching {
+		// Printable keys are typed into the query, whatever they are bound to.
+		typed := msg.Type == tea.KeyRunes
+		switch {
+		case !typed && key.Matches(msg, v.keys.Select):
+			v.searching = false
+			v.search.Blur()
+			v.query = v.search.Value()
+			v.findMatches()
+			v.nextMatch(1)
+			return v, nil, false
+		case !typed && key.Matches(msg, v.keys.Back):
+			v.searching = false
+			v.search.Blur()
+			return v, nil, false
+		}
+		var cmd tea.Cmd
+		v.search, cmd = v.search.Update(msg)
+		return v, cmd, false
+	}
+
+	switch {
+	case key.Matches(msg, v.keys.CloseDiff):
+		return v, nil, true
+	case key.Matches(msg, v.keys.NextFile):
+		v.selectFile(v.file + 1)
+		return v, nil, false
+	case key.Matches(msg, v.keys.PrevFile):
+		v.selectFile(v.file - 1)
+		return v, nil, false
+	case key.Matches(msg, v.keys.Search):
+		v.searching = true
+		v.search.SetValue("")
+		return v, v.search.Focus(), false
+	case key.Matches(msg, v.keys.NextMatch):
+		v.nextMatch(1)
+		return v, nil, false
+	case key.Matches(msg, v.keys.PrevMatch):
+		v.nextMatch(-1)
+		return v, nil, false
+	case key.Matches(msg, v.keys.Top):
+		v.viewport.GotoTop()
+		return v, nil, false
+	case key.Matches(msg, v.keys.Bottom):
+		v.viewport.GotoBottom()
+		return v, nil, false
+	}
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	return v, cmd, false
+}
+
+func (v *diffView) selectFile(i int) {
+	if i < 0 || i >= len(v.files) || i == v.file {
+		return
+	}
+	v.file = i
+	v.scrollList()
+	v.render()
+	v.viewport.GotoTop()
+}
+
+// scrollList moves the file list, which has as many rows as the viewport,
+// just enough to show the current file.
+func (v *diffView) scrollList() {
+	rows := v.viewport.Height
+	switch {
+	case v.file < v.listTop:
+		v.listTop = v.file
+	case v.file >= v.listTop+rows:
+		v.listTop = v.file - rows + 1
+	}
+	v.listTop = max(min(v.listTop, len(v.files)-rows), 0)
+}
+
+// findMatches collects the lines containing the query, ignoring case.
+func (v *diffView) findMatches() {
+	v.matches, v.match = nil, -1
+	if v.query == "" {
+		v.render()
+		return
+	}
+	q := strings.ToLower(v.query)
+	for fi, f := range v.files {
+		for li, line := range f.lines {
+			if strings.Contains(strings.ToLower(line), q) {
+				v.matches = append(v.matches, diffMatch{file: fi, line: li})
+			}
+		}
+	}
+	v.render()
+}
+
+// nextMatch moves to the next (dir 1) or previous (dir -1) match, wrapping
+// around and switching files as needed. The first jump after a search goes
+// to the first match in or after the current file.
+func (v *diffView) nextMatch(dir int) {
+	if len(v.matches) == 0 {
+		return
+	}
+	if v.match < 0 {
+		v.match = 0
+		for i, m := range v.matches {
+			if m.file >= v.file {
+				v.match = i
+				break
+			}
+		}
+	} else {
+		v.match = (v.match + dir + len(v.matches)) % len(v.matches)
+	}
+	m := v.matches[v.match]
+	v.file = m.file
+	v.scrollList()
+	v.render()
+	v.viewport.SetYOffset(m.line - v.viewport.Height/3)
+}
+
+// render sets the viewport content to the current file, highlighted by
+// highlightDiffLine with search matches marked.
+func (v *diffView) render() {
+	if len(v.files) == 0 {
+		return
+	}
+	f := v.files[v.file]
+	lexer := codeLexer(f.name)
+	lines := make([]string, len(f.lines))
+	q := strings.ToLower(v.query)
+	for i, line := range f.lines {
+		switch {
+		case v.match >= 0 && v.matches[v.match] == (diffMatch{file: v.file, line: i}):
+			lines[i] = diffCurrentMatchStyle.Render(line)
+		case q != "" && strings.Contains(strings.ToLower(line), q):
+			lines[i] = diffMatchStyle.Render(line)
+		default:
+			lines[i] = highlightDiffLine(lexer, line)
+		}
+	}
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+// codeLexer returns the lexer for the language of the file name, or nil when
+// the language is unknown or the theme does not highlight code.
+func codeLexer(name string) chroma.Lexer {
+	if codeStyle == nil {
+		return nil
+	}
+	lexer := lexers.Match(name)
+	if lexer == nil {
+		return nil
+	}
+	return chroma.Coalesce(lexer)
+}
+
+// diffSpan is a run of a diff line drawn in one style.
+type diffSpan struct {
+	text  string
+	style lipgloss.Style
+}
+
+// highlightDiffLine colours a line of the diff of a file in lexer's language.
+func highlightDiffLine(lexer chroma.Lexer, line string) string {
+	var b strings.Builder
+	for _, s := range diffLineSpans(lexer, line) {
+		b.WriteString(s.style.Render(s.text))
+	}
+	return b.String()
+}
+
+// diffLineSpans splits a diff line into styled runs. Header and hunk lines
+// are coloured by kind. On added, removed and context lines the code after
+// the marker is highlighted with lexer, added and removed lines keeping the
+// diff-add-bg or diff-del-bg background; without a lexer the whole line takes
+// the diff-add or diff-del colour.
+func diffLineSpans(lexer chroma.Lexer, line string) []diffSpan {
+	var marker, bg lipgloss.Style
+	switch {
+	case strings.HasPrefix(line, "diff --git"), strings.HasPrefix(line, "index "),
+		strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		return []diffSpan{{line, diffMetaStyle}}
+	case strings.HasPrefix(line, "@@"):
+		return []diffSpan{{line, diffHunkStyle}}
+	case strings.HasPrefix(line, "+"):
+		marker, bg = diffAddStyle.Inherit(diffAddBgStyle), diffAddBgStyle
+	case strings.HasPrefix(line, "-"):
+		marker, bg = diffDelStyle.Inherit(diffDelBgStyle), diffDelBgStyle
+	case strings.HasPrefix(line, " "):
+		marker, bg = lipgloss.NewStyle(), lipgloss.NewStyle()
+	default:
+		return []diffSpan{{line, lipgloss.NewStyle()}}
+	}
+	if lexer == nil {
+		return []diffSpan{{line, marker}}
+	}
+	tokens, err := lexer.Tokenise(nil, line[1:])
+	if err != nil {
+		return []diffSpan{{line, marker}}
+	}
+	spans := []diffSpan{{line[:1], marker}}
+	for _, t := range tokens.Tokens() {
+		// Lexers end the text with a newline the line does not have.
+		text := strings.TrimSuffix(t.Value, "\n")
+		if text == "" {
+			continue
+		}
+		spans = append(spans, diffSpan{text, tokenStyle(t.Type).Inherit(bg)})
+	}
+	return spans
+}
+
+// tokenStyle returns the codeStyle colour and weight of a token type.
+func tokenStyle(t chroma.TokenType) lipgloss.Style {
+	e := codeStyle.Get(t)
+	s := lipgloss.NewStyle().Bold(e.Bold == chroma.Yes).Italic(e.Italic == chroma.Yes)
+	if e.Colour.IsSet() {
+		s = s.Foreground(lipgloss.Color(e.Colour.String()))
+	}
+	return s
+}
+
+func (v diffView) View() string {
+	var list strings.Builder
+	w := v.listWidth()
+	end := min(v.listTop+v.viewport.Height, len(v.files))
+	for i := v.listTop; i < end; i++ {
+		f := v.files[i]
+		counts := fmt.Sprintf(" +%d -%d", f.added, f.deleted)
+		name := runewidth.Truncate(f.name, max(w-2-len(counts), 1), "…")
+		if i == v.file {
+			list.WriteString(selectedStyle.Render("> "+name) + dimStyle.Render(counts) + "\n")
+		} else {
+			list.WriteString("  " + name + dimStyle.Render(counts) + "\n")
+		}
+	}
+	files := lipgloss.NewStyle().Width(w).Render(strings.TrimSuffix(list.String(), "\n"))
+	pane := lipgloss.JoinHorizontal(lipgloss.Top, files, " ", v.viewport.View())
+
+	title := titleStyle.Render(fmt.Sprintf("Diff %d/%d: %s", v.file+1, len(v.files), v.files[v.file].name))
+	if len(v.matches) > 0 && v.match >= 0 {
+		title += subtitleStyle.Render(fmt.Sprintf("  match %d/%d", v.match+1, len(v.matches)))
+	} else if v.query != "" && len(v.matches) == 0 {
+		title += subtitleStyle.Render(fmt.Sprintf("  no match for %q", v.query))
+	}
+	k := v.keys
+	help := helpStyle.Render(k.help(pair(k.Up, k.Down, "scroll"), pair(k.NextFile, k.PrevFile, "switch file"), k.Search,
+		pair(k.NextMatch, k.PrevMatch, "jump to a match"), k.CloseDiff))
+	if v.searching {
+		help = v.search.View()
+	}
+	return title + "\n" + pane + "\n\n" + help
+}
@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hayatosc/git-cx/internal/execx"
+)
+
+const testDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@
+ package main
++import "fmt"
+-var x = 1
+diff --git a/internal/app/commit.go b/internal/app/commit.go
+index 3333333..4444444 100644
+--- a/internal/app/commit.go
++++ b/internal/app/commit.go
+@@ -10,2 +10,3 @@
++	// Retry the commit.
++	return retry()
+`
+
+func TestSplitDiff(t *testing.T) {
+	files := splitDiff(testDiff)
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(files))
+	}
+	if files[0].name != "main.go" || files[0].added != 1 || files[0].deleted != 1 {
+		t.Errorf("unexpected first file: %+v", files[0])
+	}
+	if files[1].name != "internal/app/commit.go" || files[1].added != 2 || files[1].deleted != 0 {
+		t.Errorf("unexpected second file: %+v", files[1])
+	}
+}
+
+func TestSplitDiff_empty(t *testing.T) {
+	files := splitDiff("")
+	if len(files) != 1 || files[0].name != "(no changes)" || len(files[0].lines) != 0 {
+		t.Fatalf("unexpected files: %+v", files)
+	}
+}
+
+func TestDiffView_fileNavigation(t *testing.T) {
+	v := newDiffView(testDiff, defaultKeyMap())
+	v.SetSize(100, 20)
+
+	v, _, _ = v.Update(pressKey(']'))
+	if v.file != 1 {
+		t.Fatalf("expected second file, got %d", v.file)
+	}
+	v, _, _ = v.Update(pressKey(']'))
+	if v.file != 1 {
+		t.Fatalf("expected to stay on the last file, got %d", v.file)
+	}
+	v, _, _ = v.Update(pressKey('['))
+	if v.file != 0 {
+		t.Fatalf("expected first file, got %d", v.file)
+	}
+	if view := v.View(); !strings.Contains(view, "Diff 1/2: main.go") || !strings.Contains(view, "internal/app/commit.go") {
+		t.Errorf("view missing title or file list, got: %q", view)
+	}
+}
+
+func TestDiffView_search(t *testing.T) {
+	v := newDiffView(testDiff, defaultKeyMap())
+	v.SetSize(100, 20)
+
+	v, _, _ = v.Update(pressKey('/'))
+	if !v.searching {
+		t.Fatal("expected search input after '/'")
+	}
+	for _, r := range "retry" {
+		v, _, _ = v.Update(pressKey(r))
+	}
+	v, _, _ = v.Update(pressEnter())
+	if v.searching || len(v.matches) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", v.matches)
+	}
+	if v.file != 1 || v.match != 0 {
+		t.Fatalf("expected first match in second file, got file=%d match=%d", v.file, v.match)
+	}
+	v, _, _ = v.Update(pressKey('n'))
+	v, _, _ = v.Update(pressKey('n'))
+	if v.match != 0 {
+		t.Fatalf("expected search to wrap around, got match=%d", v.match)
+	}
+	v, _, _ = v.Update(pressKey('N'))
+	if v.match != 1 {
+		t.Fatalf("expected previous match, got match=%d", v.match)
+	}
+}
+
+func TestModel_tabTogglesDiff(t *testing.T) {
+	m := New(newTestService(&execx.MockRunner{}), testDiff, "stat", false)
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = result.(Model)
+
+	result, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyTab})
+	m = result.(Model)
+	if !m.showDiff || !strings.Contains(m.View(), "Diff 1/2") {
+		t.Fatalf("expected diff viewer, got: %q", m.View())
+	}
+	result, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+	if m.showDiff || m.state != stateSelectType {
+		t.Fatalf("expected viewer closed on the type list, got showDiff=%v state=%d", m.showDiff, m.state)
+	}
+}
+
+func TestDiffLineSpans_highlightsCode(t *testing.T) {
+	spans := diffLineSpans(codeLexer("main.go"), `+	return fmt.Sprintf("%d", n)`)
+	if len(spans) < 3 || spans[0].text != "+" {
+		t.Fatalf("expected the marker and several code tokens, got %+v", spans)
+	}
+	colours := map[string]bool{}
+	for _, s := range spans {
+		if s.style.GetBackground() != diffAddBgStyle.GetBackground() {
+			t.Errorf("span %q lost the added-line background", s.text)
+		}
+		colours[fmt.Sprint(s.style.GetForeground())] = true
+	}
+	if len(colours) < 3 {
+		t.Errorf("expected code coloured by token, got %d colours in %+v", len(colours), spans)
+	}
+
+	if spans := diffLineSpans(codeLexer("notes.unknownext"), "-plain text"); len(spans) != 1 ||
+		spans[0].style.GetForeground() != diffDelStyle.GetForeground() {
+		t.Errorf("expected an unknown language to colour the whole line, got %+v", spans)
+	}
+}
+
+func TestCodeLexer_highContrast(t *testing.T) {
+	t.Cleanup(func() { applyTheme("dark", nil) })
+	applyTheme("high-contrast", nil)
+	if codeLexer("main.go") != nil {
+		t.Error("expected no code highlighting in the high-contrast theme")
+	}
+}
+
+func TestDiffView_fileListScrolls(t *testing.T) {
+	var diff strings.Builder
+	for i := range 12 {
+		fmt.Fprintf(&diff, "diff --git a/file%02d.txt b/file%02d.txt\n+line\n", i, i)
+	}
+	v := newDiffView(diff.String(), defaultKeyMap())
+	v.SetSize(100, 8) // five rows for the file list
+
+	for range 9 {
+		v, _, _ = v.Update(pressKey(']'))
+	}
+	view := v.View()
+	if !strings.Contains(view, "> file09.txt") {
+		t.Fatalf("expected the selected file in the list, got: %q", view)
+	}
+	if strings.Contains(view, "file04.txt") || !strings.Contains(view, "file05.txt") {
+		t.Errorf("expected the list to scroll just enough, got: %q", view)
+	}
+	if lines := strings.Count(view, "\n") + 1; lines > 8 {
+		t.Errorf("expected the view to fit 8 lines, got %d", lines)
+	}
+
+	for range 9 {
+		v, _, _ = v.Update(pressKey('['))
+	}
+	if view := v.View(); !strings.Contains(view, "> file00.txt") || strings.Contains(view, "file05.txt") {
+		t.Errorf("expected the list to scroll back to the top, got: %q", view)
+	}
+}
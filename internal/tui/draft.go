@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hayatosc/git-cx/internal/app"
+	"github.com/hayatosc/git-cx/internal/commit"
+)
+
+// draft captures the message built so far.
+func (m Model) draft() app.Draft {
+	return app.Draft{
+		Type:         m.commitType,
+		Scope:        m.scope,
+		Emoji:        m.emoji,
+		Subject:      m.subject,
+		Breaking:     m.breaking,
+		BreakingNote: m.breakingNote,
+		Body:         m.bodyText,
+		Footer:       commit.FormatFooters(m.trailers),
+		Candidates:   m.candidates,
+		Rationales:   m.rationales,
+	}
+}
+
+// saveDraft stores the progress when drafts are enabled. It is best effort:
+// a draft that cannot be written must not get in the way of the commit.
+func (m Model) saveDraft() {
+	if !m.drafts || m.state == stateRestoreDraft {
+		return
+	}
+	_ = m.service.SaveDraft(context.Background(), m.draft())
+}
+
+// applyDraft fills the message fields from d.
+func (m Model) applyDraft(d app.Draft) Model {
+	m.commitType, m.scope, m.emoji, m.subject = d.Type, d.Scope, d.Emoji, d.Subject
+	m.breaking, m.breakingNote = d.Breaking, d.BreakingNote
+	m.bodyText = d.Body
+	if footers, ok := commit.ParseFooters(d.Footer); ok {
+		m.trailers = footers
+	}
+	return m
+}
+
+// restoreDraft continues from the saved draft: at Confirm when it has a
+// subject, otherwise at its candidates. The steps before are on the back
+// stack as if they had been walked through.
+func (m Model) restoreDraft() (tea.Model, tea.Cmd) {
+	d := m.savedDraft
+	m = m.applyDraft(d)
+	m.history = []State{stateSelectType, stateInputScope}
+	subjectStep := stateInputMsg
+	if len(d.Candidates) > 0 {
+		next, _ := m.handleAIResult(aiResultMsg{candidates: d.Candidates, rationales: d.Rationales})
+		m = next.(Model)
+		subjectStep = stateSelectMsg
+	}
+	if d.Subject == "" {
+		return m, nil
+	}
+	m.history = append(m.history, subjectStep, stateSelectDetailMode, stateEditTrailers)
+	return m.enterConfirm(), nil
+}
+
+func (m Model) handleRestoreDraftKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Commit, m.keys.Select):
+		return m.restoreDraft()
+	case key.Matches(msg, m.keys.Abort, m.keys.Back):
+		m.state = stateSelectType
+		return m, nil
+	}
+	return m, nil
+}
+
+// previewDraft renders the message d would restore, or its candidates when
+// none was picked yet.
+func (m Model) previewDraft(d app.Draft) string {
+	restored := m.applyDraft(d)
+	if restored.subject == "" {
+		return "AI candidates:\n" + strings.Join(d.Candidates, "\n")
+	}
+	preview, _ := m.service.FinalMessage(context.Background(), restored.conventionalCommit(), restored.trailers)
+	return preview
+}
+
+func (m Model) viewRestoreDraft() string {
+	return fmt.Sprintf(
+		"%s\n\n%s\n\n%s",
+		titleStyle.Render("Restore the unfinished commit message?"),
+		previewStyle.Render(m.draftPreview),
+		helpStyle.Render(m.keys.help(withDesc(m.keys.Commit, "restore"), withDesc(m.keys.Abort, "start over"), m.keys.Quit)),
+	)
+}
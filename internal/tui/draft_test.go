@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hayatosc/git-cx/internal/execx"
+)
+
+// newDraftModel returns a model that keeps its drafts in a temporary file.
+func newDraftModel(t *testing.T, path string) (Model, *execx.MockRunner) {
+	t.Helper()
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00write-tree": {Stdout: "abc\n"},
+			"git\x00rev-parse\x00--git-path\x00cx/draft.json": {Stdout: path + "\n"},
+		},
+	}
+	m := New(newTestService(mock), "diff", "stat", false).WithDrafts()
+	m, _ = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 30})
+	return m, mock
+}
+
+func TestDraft_savedAndRestored(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "draft.json")
+	m, _ := newDraftModel(t, path)
+	if m.state != stateSelectType {
+		t.Fatalf("expected no draft to restore, got %v", m.state)
+	}
+	m, _ = update(t, m, pressEnter()) // auto
+	m = typeText(t, m, "core")
+	m, _ = update(t, m, pressEnter())
+	m, _ = update(t, m, aiResultMsg{candidates: []string{"feat(core): add x"}})
+	m, _ = update(t, m, pressEnter())
+	m.detailList.Select(2) // [Manual entry]
+	m, _ = update(t, m, pressEnter())
+	m = typeText(t, m, "typed body")
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyCtrlC})
+
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), `"body": "typed body"`) {
+		t.Fatalf("expected the typed body in the draft, got %s (%v)", data, err)
+	}
+
+	m, _ = newDraftModel(t, path)
+	if m.state != stateRestoreDraft || !strings.Contains(m.View(), "typed body") {
+		t.Fatalf("expected the draft to be offered, got state %v:\n%s", m.state, m.View())
+	}
+	m, _ = update(t, m, pressKey('y'))
+	if m.state != stateConfirm || m.subject != "feat(core): add x" || m.bodyText != "typed body" {
+		t.Fatalf("expected Confirm with the draft, got state %v subject %q body %q", m.state, m.subject, m.bodyText)
+	}
+	if !strings.Contains(m.preview, "typed body") {
+		t.Fatalf("expected the body in the preview, got %q", m.preview)
+	}
+
+	m, _ = update(t, m, pressEsc())
+	m, _ = update(t, m, pressEsc())
+	m, _ = update(t, m, pressEsc())
+	if m.state != stateSelectMsg || len(m.msgList.Items()) != 4 {
+		t.Fatalf("expected the restored candidates, got state %v with %d items", m.state, len(m.msgList.Items()))
+	}
+}
+
+func TestDraft_startOver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "draft.json")
+	m, _ := newDraftModel(t, path)
+	m.candidates = []string{"feat: x"}
+	m.saveDraft()
+
+	m, _ = newDraftModel(t, path)
+	m, _ = update(t, m, pressKey('n'))
+	if m.state != stateSelectType || m.subject != "" || len(m.candidates) != 0 {
+		t.Fatalf("expected a fresh start, got state %v", m.state)
+	}
+}
+
+func TestDraft_clearedAfterCommit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "draft.json")
+	m, _ := newDraftModel(t, path)
+	m.commitType, m.subject = "feat", "add x"
+	m.saveDraft()
+
+	if done := m.doCommit()().(commitDoneMsg); done.err != nil {
+		t.Fatalf("unexpected error: %v", done.err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected the draft to be removed, got %v", err)
+	}
+}
+
+func TestDraft_keptUntilHookCommit(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "draft.json")
+	msgFile := filepath.Join(dir, "COMMIT_EDITMSG")
+	if err := os.WriteFile(msgFile, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := newDraftModel(t, path)
+	m.service.SetMessageFile(msgFile)
+	m.commitType, m.subject = "feat", "add x"
+	m.saveDraft()
+
+	if done := m.doCommit()().(commitDoneMsg); done.err != nil {
+		t.Fatalf("unexpected error: %v", done.err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the draft to be kept until git commits, got %v", err)
+	}
+
+	// git committed the message, so HEAD has moved on the next run.
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00write-tree": {Stdout: "abc\n"},
+			"git\x00rev-parse\x00--git-path\x00cx/draft.json": {Stdout: path + "\n"},
+			"git\x00rev-parse\x00--verify\x00--quiet\x00HEAD": {Stdout: "def\n"},
+		},
+	}
+	m = New(newTestService(mock), "diff", "stat", false).WithDrafts()
+	if m.state == stateRestoreDraft {
+		t.Fatal("a committed draft must not be offered")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected the committed draft to be removed, got %v", err)
+	}
+}
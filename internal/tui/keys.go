@@ -0,0 +1,228 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hayatosc/git-cx/internal/config"
+)
+
+// keyMap holds the key bindings of every screen. Text inputs use Select to
+// submit and Back to cancel; printable keys bound there are typed instead.
+type keyMap struct {
+	Up, Down, Select, Back, Quit, Help key.Binding
+
+	Diff, Editor, RetryAI, Edit key.Binding
+
+	// Done submits a multi-line text, where Enter starts a new line;
+	// NextField and PrevField move between the candidate editor's fields.
+	Done, NextField, PrevField key.Binding
+
+	Commit, Abort, Breaking, Retry, NoVerify key.Binding
+
+	AddTrailer, CoAuthor, EditTrailer, DeleteTrailer key.Binding
+
+	// Bindings of the diff viewer, which scrolls with Up and Down.
+	CloseDiff, NextFile, PrevFile, Search, NextMatch, PrevMatch, Top, Bottom key.Binding
+}
+
+func binding(label, desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(label, desc))
+}
+
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Up:            binding("↑/k", "move up", "up", "k"),
+		Down:          binding("↓/j", "move down", "down", "j"),
+		Select:        binding("Enter", "select", "enter"),
+		Back:          binding("Esc", "go back", "esc"),
+		Quit:          binding("Ctrl+C", "quit", "ctrl+c"),
+		Help:          binding("?", "show all keys", "?"),
+		Diff:          binding("Tab", "view diff", "tab"),
+		Editor:        binding("Ctrl+E", "edit in editor", "ctrl+e"),
+		RetryAI:       binding("Ctrl+R", "retry AI", "ctrl+r"),
+		Edit:          binding("e", "edit", "e"),
+		Done:          binding("Tab", "confirm", "tab"),
+		NextField:     binding("Tab/↓", "next field", "tab", "down"),
+		PrevField:     binding("Shift+Tab/↑", "previous field", "shift+tab", "up"),
+		Commit:        binding("y/Enter", "commit", "y", "Y", "enter"),
+		Abort:         binding("n/q", "abort", "n", "N", "q", "Q"),
+		Breaking:      binding("b", "toggle breaking change", "b", "B"),
+		Retry:         binding("r", "retry", "r", "R"),
+		NoVerify:      binding("v", "retry with --no-verify", "v", "V"),
+		AddTrailer:    binding("a", "add", "a"),
+		CoAuthor:      binding("c", "add co-author", "c"),
+		EditTrailer:   binding("e", "edit", "e"),
+		DeleteTrailer: binding("d", "delete", "d", "delete", "backspace"),
+		CloseDiff:     binding("Tab/Esc/q", "close the diff", "tab", "esc", "q"),
+		NextFile:      binding("]", "next file", "]", "ctrl+n"),
+		PrevFile:      binding("[", "previous file", "[", "ctrl+p"),
+		Search:        binding("/", "search", "/"),
+		NextMatch:     binding("n", "next match", "n"),
+		PrevMatch:     binding("N", "previous match", "N"),
+		Top:           binding("g", "go to top", "g", "home"),
+		Bottom:        binding("G", "go to bottom", "G", "end"),
+	}
+}
+
+// vimKeyMap adds vim's keys: w(rite) commits, q(uit) aborts, i(nsert)
+// edits, o(pen) adds and x deletes.
+func vimKeyMap() keyMap {
+	k := defaultKeyMap()
+	k.Edit = binding("e/i", "edit", "e", "i")
+	k.Commit = binding("y/w/Enter", "commit", "y", "w", "enter")
+	k.Abort = binding("n/q", "abort", "n", "q")
+	k.AddTrailer = binding("a/o", "add", "a", "o")
+	k.EditTrailer = binding("e/i", "edit", "e", "i")
+	k.DeleteTrailer = binding("d/x", "delete", "d", "x", "delete", "backspace")
+	return k
+}
+
+// newKeyMap builds the bindings for cx.ui.keymap with cx.ui.key.<action>
+// on top.
+func newKeyMap(ui config.UIConfig) keyMap {
+	k := defaultKeyMap()
+	if ui.Keymap == "vim" {
+		k = vimKeyMap()
+	}
+	actions := k.actions()
+	for action, keys := range ui.Keys {
+		if b, ok := actions[action]; ok && len(keys) > 0 {
+			b.SetKeys(keys...)
+			labels := make([]string, len(keys))
+			for i, s := range keys {
+				labels[i] = keyLabel(s)
+			}
+			b.SetHelp(strings.Join(labels, "/"), b.Help().Desc)
+		}
+	}
+	return k
+}
+
+// actions maps the names in config.UIKeyActions to the bindings. A new
+// binding needs a name here and in config.UIKeyActions.
+func (k *keyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":             &k.Up,
+		"down":           &k.Down,
+		"select":         &k.Select,
+		"back":           &k.Back,
+		"quit":           &k.Quit,
+		"help":           &k.Help,
+		"diff":           &k.Diff,
+		"editor":         &k.Editor,
+		"retry-ai":       &k.RetryAI,
+		"edit":           &k.Edit,
+		"done":           &k.Done,
+		"next-field":     &k.NextField,
+		"prev-field":     &k.PrevField,
+		"commit":         &k.Commit,
+		"abort":          &k.Abort,
+		"breaking":       &k.Breaking,
+		"retry":          &k.Retry,
+		"no-verify":      &k.NoVerify,
+		"add-trailer":    &k.AddTrailer,
+		"co-author":      &k.CoAuthor,
+		"edit-trailer":   &k.EditTrailer,
+		"delete-trailer": &k.DeleteTrailer,
+		"close-diff":     &k.CloseDiff,
+		"next-file":      &k.NextFile,
+		"prev-file":      &k.PrevFile,
+		"search":         &k.Search,
+		"next-match":     &k.NextMatch,
+		"prev-match":     &k.PrevMatch,
+		"top":            &k.Top,
+		"bottom":         &k.Bottom,
+	}
+}
+
+// keyLabel spells a bubbletea key name the way the help lines do, e.g.
+// "ctrl+s" as "Ctrl+S".
+func keyLabel(name string) string {
+	switch name {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	}
+	parts := strings.Split(name, "+")
+	for i, p := range parts {
+		switch {
+		case len(p) > 1:
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		case len(parts) > 1:
+			parts[i] = strings.ToUpper(p)
+		}
+	}
+	return strings.Join(parts, "+")
+}
+
+// withDesc returns b described as desc, for screens where the action reads
+// differently (e.g. Esc cancels while loading).
+func withDesc(b key.Binding, desc string) key.Binding {
+	b.SetHelp(b.Help().Key, desc)
+	return b
+}
+
+// pair describes bindings a and b together, as in "]/[ to switch file".
+func pair(a, b key.Binding, desc string) key.Binding {
+	return key.NewBinding(key.WithKeys(append(a.Keys(), b.Keys()...)...), key.WithHelp(a.Help().Key+"/"+b.Help().Key, desc))
+}
+
+// help renders a help line such as "Enter to select • Esc to go back".
+func (k keyMap) help(bindings ...key.Binding) string {
+	parts := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		if b.Enabled() {
+			parts = append(parts, b.Help().Key+" to "+b.Help().Desc)
+		}
+	}
+	return strings.Join(parts, " • ")
+}
+
+// fullHelp groups every binding for the help screen.
+func (k keyMap) fullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Select, k.Back, k.Help, k.Quit},
+		{k.Diff, k.Editor, k.RetryAI, k.Edit},
+		{k.Done, k.NextField, k.PrevField},
+		{k.Commit, k.Abort, k.Breaking, k.Retry, k.NoVerify},
+		{k.AddTrailer, k.CoAuthor, k.EditTrailer, k.DeleteTrailer},
+		{k.CloseDiff, k.NextFile, k.PrevFile, k.Search, k.NextMatch, k.PrevMatch, k.Top, k.Bottom},
+	}
+}
+
+// listKeys makes l move with the Up and Down bindings.
+func (k keyMap) listKeys(l list.Model) list.Model {
+	l.KeyMap.CursorUp = k.Up
+	l.KeyMap.CursorDown = k.Down
+	return l
+}
+
+// helpView renders the help screen opened with the Help binding. The groups
+// are laid out side by side and wrap onto further rows to fit width.
+func (k keyMap) helpView(width int) string {
+	h := help.New()
+	h.FullSeparator = "    "
+	h.Styles.FullKey = selectedStyle
+	h.Styles.FullDesc = lipgloss.NewStyle()
+	h.Styles.FullSeparator = dimStyle
+	var rows []string
+	var row [][]key.Binding
+	for _, group := range k.fullHelp() {
+		wider := append(slices.Clip(row), group)
+		if len(row) > 0 && width > 0 && lipgloss.Width(h.FullHelpView(wider)) > width {
+			rows = append(rows, h.FullHelpView(row))
+			wider = [][]key.Binding{group}
+		}
+		row = wider
+	}
+	rows = append(rows, h.FullHelpView(row))
+	return titleStyle.Render("Key bindings") + "\n\n" + strings.Join(rows, "\n\n") + "\n\n" +
+		helpStyle.Render(k.help(withDesc(k.Help, "close"), withDesc(k.Back, "close")))
+}
@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hayatosc/git-cx/internal/config"
+)
+
+func TestKeyLabel(t *testing.T) {
+	tests := map[string]string{
+		"ctrl+s": "Ctrl+S",
+		"enter":  "Enter",
+		"q":      "q",
+		"up":     "↑",
+		"?":      "?",
+	}
+	for name, want := range tests {
+		if got := keyLabel(name); got != want {
+			t.Errorf("keyLabel(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestNewKeyMap_overrides(t *testing.T) {
+	k := newKeyMap(config.UIConfig{Keymap: "vim", Keys: map[string][]string{
+		"commit":  {"ctrl+s"},
+		"unknown": {"x"},
+	}})
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyCtrlS}, k.Commit) || key.Matches(pressKey('y'), k.Commit) {
+		t.Fatalf("expected commit to be rebound to ctrl+s, got %v", k.Commit.Keys())
+	}
+	if got := k.help(k.Commit); got != "Ctrl+S to commit" {
+		t.Fatalf("unexpected help: %q", got)
+	}
+	if !key.Matches(pressKey('x'), k.DeleteTrailer) {
+		t.Fatalf("expected the vim keymap to delete with x")
+	}
+	if key.Matches(pressKey('x'), defaultKeyMap().DeleteTrailer) {
+		t.Fatalf("expected the default keymap not to bind x")
+	}
+}
+
+func TestKeyMap_actionsMatchConfig(t *testing.T) {
+	k := defaultKeyMap()
+	actions := k.actions()
+	got := slices.Sorted(maps.Keys(actions))
+	want := slices.Sorted(slices.Values(config.UIKeyActions))
+	if !slices.Equal(got, want) {
+		t.Fatalf("keyMap actions and config.UIKeyActions differ:\n got %v\nwant %v", got, want)
+	}
+
+	// Every binding is reachable by exactly one action name.
+	bound := map[*key.Binding]string{}
+	for name, b := range actions {
+		if other, ok := bound[b]; ok {
+			t.Errorf("actions %q and %q share a binding", name, other)
+		}
+		bound[b] = name
+	}
+	v := reflect.ValueOf(&k).Elem()
+	for i := range v.NumField() {
+		if b, ok := v.Field(i).Addr().Interface().(*key.Binding); ok && bound[b] == "" {
+			t.Errorf("keyMap.%s has no action name", v.Type().Field(i).Name)
+		}
+	}
+}
+
+func TestKeys_reboundConfirm(t *testing.T) {
+	m := newModel(false)
+	m.keys = newKeyMap(config.UIConfig{Keys: map[string][]string{"abort": {"ctrl+q"}}})
+	m.state = stateConfirm
+	m.preview = "feat: x"
+
+	if view := m.View(); !strings.Contains(view, "Ctrl+Q to abort") {
+		t.Fatalf("expected the help line to follow the binding, got:\n%s", view)
+	}
+	m, cmd := update(t, m, pressKey('n'))
+	if m.quitting || cmd != nil {
+		t.Fatalf("expected n to be unbound")
+	}
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyCtrlQ})
+	if !m.quitting {
+		t.Fatalf("expected ctrl+q to abort")
+	}
+}
+
+func TestKeys_letterBindingIgnoredWhileTyping(t *testing.T) {
+	m := newModel(false)
+	m.keys = newKeyMap(config.UIConfig{Keys: map[string][]string{"quit": {"q"}}})
+	m.state = stateInputScope
+	m.input.Focus()
+
+	m, _ = update(t, m, pressKey('q'))
+	if m.quitting || m.input.Value() != "q" {
+		t.Fatalf("expected q to be typed, got quitting %v value %q", m.quitting, m.input.Value())
+	}
+}
+
+func TestKeys_reboundTextInput(t *testing.T) {
+	m := newModel(false)
+	m.keys = newKeyMap(config.UIConfig{Keys: map[string][]string{"done": {"ctrl+s"}, "back": {"ctrl+g"}}})
+	m.state = stateInputBody
+	m.body.Focus()
+
+	if view := m.View(); !strings.Contains(view, "Ctrl+S to confirm") {
+		t.Fatalf("expected the help line to follow the binding, got:\n%s", view)
+	}
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	if m.state != stateInputBody {
+		t.Fatalf("expected Tab to be unbound, got %v", m.state)
+	}
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.state != stateEditTrailers {
+		t.Fatalf("expected ctrl+s to confirm the body, got %v", m.state)
+	}
+
+	m = m.inputTrailer(-1)
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyCtrlG})
+	if m.state != stateEditTrailers {
+		t.Fatalf("expected ctrl+g to cancel the trailer, got %v", m.state)
+	}
+}
+
+func TestKeys_reboundDiffViewer(t *testing.T) {
+	v := newDiffView(testDiff, newKeyMap(config.UIConfig{Keys: map[string][]string{"close-diff": {"x"}, "next-file": {"ctrl+f"}}}))
+	v.SetSize(100, 20)
+
+	v, _, _ = v.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	if v.file != 1 {
+		t.Fatalf("expected ctrl+f to show the next file, got %d", v.file)
+	}
+	if _, _, closed := v.Update(tea.KeyMsg{Type: tea.KeyEsc}); closed {
+		t.Fatalf("expected Esc to be unbound")
+	}
+	if _, _, closed := v.Update(pressKey('x')); !closed {
+		t.Fatalf("expected x to close the viewer")
+	}
+	if view := v.View(); !strings.Contains(view, "x to close the diff") {
+		t.Errorf("expected the help line to follow the binding, got:\n%s", view)
+	}
+}
+
+func TestHelpScreen(t *testing.T) {
+	m, _ := update(t, newModel(false), tea.WindowSizeMsg{Width: 80, Height: 30})
+	m, _ = update(t, m, pressKey('?'))
+	if !m.showHelp {
+		t.Fatalf("expected ? to open the help screen")
+	}
+	view := m.View()
+	for _, want := range []string{"Key bindings", "toggle breaking change", "add co-author"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("help screen missing %q:\n%s", want, view)
+		}
+	}
+	m, _ = update(t, m, pressEnter())
+	if !m.showHelp || m.state != stateSelectType {
+		t.Fatalf("expected other keys to be ignored while the help is open")
+	}
+	m, _ = update(t, m, pressEsc())
+	if m.showHelp || m.state != stateSelectType {
+		t.Fatalf("expected Esc to close the help, got showHelp %v state %v", m.showHelp, m.state)
+	}
+}
+
+func TestApplyTheme(t *testing.T) {
+	t.Cleanup(func() { applyTheme("dark", nil) })
+
+	applyTheme("light", map[string]string{"error": "#ff0000"})
+	if got := titleStyle.GetForeground(); got != lipgloss.Color("162") {
+		t.Errorf("expected light title colour, got %v", got)
+	}
+	if got := errorStyle.GetForeground(); got != lipgloss.Color("#ff0000") {
+		t.Errorf("expected overridden error colour, got %v", got)
+	}
+
+	applyTheme("high-contrast", nil)
+	if _, ok := helpStyle.GetForeground().(lipgloss.NoColor); !ok {
+		t.Errorf("expected help in the terminal's colour, got %v", helpStyle.GetForeground())
+	}
+}
@@ -2,8 +2,13 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
@@ -12,6 +17,8 @@ import (
 
 	"github.com/hayatosc/git-cx/internal/app"
 	"github.com/hayatosc/git-cx/internal/commit"
+	"github.com/hayatosc/git-cx/internal/execx"
+	"github.com/hayatosc/git-cx/internal/git"
 )
 
 // State represents TUI step.
@@ -23,12 +30,21 @@ const (
 	stateAILoading
 	stateSelectMsg
 	stateInputMsg
+	stateEditMsg
+	stateInputHint
+	stateSelectGitmoji
 	stateSelectDetailMode
 	stateDetailAILoading
 	stateInputBody
-	stateInputFooter
+	stateEditTrailers
+	stateInputTrailer
+	stateSelectCoAuthor
+	stateInputBreaking
+	stateBreakingAILoading
 	stateConfirm
+	stateCommitFailed
 	stateDone
+	stateRestoreDraft
 )
 
 // item is a simple list.Item implementation.
@@ -40,9 +56,21 @@ func (i item) Title() string       { return i.title }
 func (i item) Description() string { return i.desc }
 func (i item) FilterValue() string { return i.title }
 
+// gitmojiItem lists a gitmoji, filterable by shortcode, description and type.
+type gitmojiItem struct {
+	commit.Gitmoji
+}
+
+func (i gitmojiItem) Title() string       { return i.Emoji + "  " + i.Shortcode() }
+func (i gitmojiItem) Description() string { return i.Gitmoji.Description }
+func (i gitmojiItem) FilterValue() string {
+	return i.Code + " " + i.Gitmoji.Description + " " + i.Type
+}
+
 // aiResultMsg carries the AI generation result.
 type aiResultMsg struct {
 	candidates []string
+	rationales []string // the model's reason for each candidate, if given
 	err        error
 }
 
@@ -53,6 +81,32 @@ type aiDetailResultMsg struct {
 	err    error
 }
 
+// aiBreakingResultMsg carries the AI-generated BREAKING CHANGE note.
+type aiBreakingResultMsg struct {
+	note string
+	err  error
+}
+
+// coAuthorsMsg carries co-author suggestions for the trailer editor.
+type coAuthorsMsg struct {
+	coAuthors []app.CoAuthor
+	err       error
+}
+
+// operationResultMsg carries the message for an in-progress merge, revert
+// or cherry-pick.
+type operationResultMsg struct {
+	subject string
+	body    string
+	err     error
+}
+
+// editorDoneMsg carries the message saved in $EDITOR.
+type editorDoneMsg struct {
+	message string
+	err     error
+}
+
 // commitDoneMsg signals that git commit completed.
 type commitDoneMsg struct {
 	err     error
@@ -60,26 +114,60 @@ type commitDoneMsg struct {
 	output  string
 }
 
+// scopePlaceholder is shown in the empty scope input.
+const scopePlaceholder = "(optional) scope, press Enter to skip"
+
 // Model is the bubbletea model.
 type Model struct {
 	state   State
+	history []State // steps to go back to with Esc, most recent last
 	service *app.CommitService
 	diff    string
 	stat    string
 
-	typeList   list.Model
-	msgList    list.Model
-	detailList list.Model
-	input      textinput.Model
-	body       textarea.Model
-	spin       spinner.Model
-
-	commitType string
-	scope      string
-	candidates []string
-	subject    string
-	bodyText   string
-	footer     string
+	operation     git.Operation
+	loadOperation tea.Cmd // started by Init in operation mode
+
+	typeList     list.Model
+	msgList      list.Model
+	detailList   list.Model
+	gitmojiList  list.Model
+	coAuthorList list.Model
+	input        textinput.Model
+	editFields   [3]textinput.Model // type, scope and subject in stateEditMsg
+	editFocus    int
+	editEmoji    string // gitmoji of the candidate being edited
+	body         textarea.Model
+	spin         spinner.Model
+	cancelAI     context.CancelFunc // cancels the running AI request
+	aiDone       <-chan struct{}    // closed once the running AI request returned
+	aiStarted    time.Time
+	diffView     diffView
+	showDiff     bool
+	keys         keyMap
+	showHelp     bool
+
+	commitType    string
+	scope         string
+	candidates    []string
+	rationales    []string // parallel to candidates
+	candidatesFor string   // candidatesKey of the request candidates answer
+	aiDetail      aiDetailResultMsg
+	aiDetailFor   string // detailKey of the request aiDetail answers
+	subject       string
+	emoji         string
+	bodyText      string
+
+	defaultTrailers []commit.Footer
+	trailers        []commit.Footer
+	trailerCursor   int
+	trailerEdit     int // row edited in stateInputTrailer, -1 for a new one
+
+	preview    string
+	previewErr error
+
+	breaking     bool
+	breakingNote string
 
 	err      error
 	quitting bool
@@ -88,21 +176,30 @@ type Model struct {
 	dryRunMsg string
 	logOutput string
 
+	drafts       bool      // save progress with SaveDraft
+	savedDraft   app.Draft // offered in stateRestoreDraft
+	draftPreview string
+
 	width  int
 	height int
 }
 
 // New creates a new TUI Model.
 func New(service *app.CommitService, diff, stat string, dryRun bool) Model {
+	ui := service.UI()
+	applyTheme(ui.Theme, ui.Colors)
+	keys := newKeyMap(ui)
+
 	// Type selector list
-	typeItems := make([]list.Item, len(commit.CommitTypes))
-	for i, t := range commit.CommitTypes {
-		typeItems[i] = item{title: t, desc: commit.CommitTypeDescriptions[t]}
+	typeItems := []list.Item{item{title: commit.AutoType, desc: commit.AutoTypeDescription}}
+	for _, t := range service.Types() {
+		typeItems = append(typeItems, item{title: t.Name, desc: t.Description})
 	}
 	typeList := list.New(typeItems, list.NewDefaultDelegate(), 0, 0)
 	typeList.Title = "Select commit type"
 	typeList.SetShowStatusBar(false)
 	typeList.SetFilteringEnabled(false)
+	typeList = keys.listKeys(typeList)
 
 	detailItems := []list.Item{
 		item{title: "[Skip]", desc: "Skip body and footer"},
@@ -113,13 +210,14 @@ func New(service *app.CommitService, diff, stat string, dryRun bool) Model {
 	detailList.Title = "Select detail input"
 	detailList.SetShowStatusBar(false)
 	detailList.SetFilteringEnabled(false)
+	detailList = keys.listKeys(detailList)
 
 	inp := textinput.New()
 	inp.Placeholder = "(optional) press Enter to skip"
 	inp.Focus()
 
 	ta := textarea.New()
-	ta.Placeholder = "(optional) press Esc to skip, Tab to confirm"
+	ta.Placeholder = "(optional) leave empty to skip"
 	ta.SetWidth(60)
 	ta.SetHeight(5)
 
@@ -127,18 +225,47 @@ func New(service *app.CommitService, diff, stat string, dryRun bool) Model {
 	sp.Spinner = spinner.Dot
 	sp.Style = selectedStyle
 
+	trailers := service.DefaultTrailers(context.Background())
+
 	return Model{
-		state:      stateSelectType,
-		service:    service,
-		diff:       diff,
-		stat:       stat,
-		typeList:   typeList,
-		detailList: detailList,
-		input:      inp,
-		body:       ta,
-		spin:       sp,
-		dryRun:     dryRun,
+		state:           stateSelectType,
+		service:         service,
+		diff:            diff,
+		stat:            stat,
+		typeList:        typeList,
+		detailList:      detailList,
+		input:           inp,
+		body:            ta,
+		spin:            sp,
+		diffView:        newDiffView(diff, keys),
+		keys:            keys,
+		defaultTrailers: trailers,
+		trailers:        trailers,
+		dryRun:          dryRun,
+	}
+}
+
+// WithOperation makes the model conclude op: the message is derived from the
+// merge, revert or cherry-pick instead of picking a type and candidates.
+func (m Model) WithOperation(op git.Operation) Model {
+	m.operation = op
+	if op.Kind != git.OperationNone {
+		m, m.loadOperation = m.startAI(stateAILoading, m.generateOperation)
+	}
+	return m
+}
+
+// WithDrafts makes the model save its progress as a draft at every step, so
+// that it survives a failed commit or a closed terminal. A draft left for the
+// staged changes by an earlier run is offered for restoring first.
+func (m Model) WithDrafts() Model {
+	m.drafts = true
+	if d, ok := m.service.LoadDraft(context.Background()); ok {
+		m.savedDraft = d
+		m.draftPreview = m.previewDraft(d)
+		m.state = stateRestoreDraft
 	}
+	return m
 }
 
 // LogOutput returns git commit output (stdout+stderr) if available.
@@ -147,19 +274,41 @@ func (m Model) LogOutput() string {
 }
 
 func (m Model) Init() tea.Cmd {
+	if m.loadOperation != nil {
+		return m.loadOperation
+	}
 	return m.spin.Tick
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	prev, depth := m.state, len(m.history)
+	next, cmd := m.update(msg)
+	if nm, ok := next.(Model); ok {
+		if nm.state != prev {
+			nm.saveDraft()
+		}
+		return nm.record(prev, depth), cmd
+	}
+	return next, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		m.typeList.SetSize(msg.Width, msg.Height-4)
 		m.detailList.SetSize(msg.Width, msg.Height-4)
+		m.diffView.SetSize(msg.Width, msg.Height)
 		if len(m.msgList.Items()) > 0 {
 			m.msgList.SetSize(msg.Width, msg.Height-4)
 		}
+		if len(m.gitmojiList.Items()) > 0 {
+			m.gitmojiList.SetSize(msg.Width, msg.Height-4)
+		}
+		if len(m.coAuthorList.Items()) > 0 {
+			m.coAuthorList.SetSize(msg.Width, msg.Height-4)
+		}
 		return m, nil
 
 	case tea.KeyMsg:
@@ -176,9 +325,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case aiDetailResultMsg:
 		return m.handleAIDetailResult(msg)
 
+	case operationResultMsg:
+		return m.handleOperationResult(msg)
+
+	case aiBreakingResultMsg:
+		return m.handleAIBreakingResult(msg)
+
+	case coAuthorsMsg:
+		return m.handleCoAuthors(msg)
+
+	case editorDoneMsg:
+		return m.handleEditorDone(msg)
+
 	case commitDoneMsg:
 		if msg.err != nil {
 			m.err = msg.err
+			m.logOutput = msg.output
+			m.state = stateCommitFailed
+			return m, nil
 		}
 		m.dryRunMsg = msg.message
 		m.logOutput = msg.output
@@ -190,10 +354,48 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if msg.Type == tea.KeyCtrlC {
+	if m.matches(msg, m.keys.Quit) {
 		m.quitting = true
+		if m.state == stateInputBody {
+			m.bodyText = m.body.Value()
+		}
+		m.saveDraft()
+		if isAILoading(m.state) {
+			// Quit only once the provider's processes are gone.
+			return m, tea.Sequence(m.stopAI(), tea.Quit)
+		}
 		return m, tea.Quit
 	}
+	if m.showDiff {
+		var cmd tea.Cmd
+		var closed bool
+		m.diffView, cmd, closed = m.diffView.Update(msg)
+		m.showDiff = !closed
+		return m, cmd
+	}
+	if m.showHelp {
+		if key.Matches(msg, m.keys.Help, m.keys.Back) {
+			m.showHelp = false
+		}
+		return m, nil
+	}
+	if key.Matches(msg, m.keys.Help) && m.canShowHelp() {
+		m.showHelp = true
+		return m, nil
+	}
+	if m.matches(msg, m.keys.Back) && isAILoading(m.state) {
+		return m.cancelAIRequest()
+	}
+	if m.matches(msg, m.keys.Back) && m.canGoBack() {
+		return m.back()
+	}
+	if m.matches(msg, m.keys.Editor) && m.canOpenEditor() {
+		return m.openEditor()
+	}
+	if m.matches(msg, m.keys.Diff) && m.canShowDiff() {
+		m.showDiff = true
+		return m, nil
+	}
 
 	switch m.state {
 	case stateSelectType:
@@ -204,26 +406,166 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleSelectMsgKey(msg)
 	case stateInputMsg:
 		return m.handleInputMsgKey(msg)
+	case stateEditMsg:
+		return m.handleEditMsgKey(msg)
+	case stateInputHint:
+		return m.handleInputHintKey(msg)
+	case stateSelectGitmoji:
+		return m.handleSelectGitmojiKey(msg)
 	case stateSelectDetailMode:
 		return m.handleSelectDetailModeKey(msg)
 	case stateInputBody:
 		return m.handleInputBodyKey(msg)
-	case stateInputFooter:
-		return m.handleInputFooterKey(msg)
+	case stateEditTrailers:
+		return m.handleEditTrailersKey(msg)
+	case stateInputTrailer:
+		return m.handleInputTrailerKey(msg)
+	case stateSelectCoAuthor:
+		return m.handleSelectCoAuthorKey(msg)
+	case stateInputBreaking:
+		return m.handleInputBreakingKey(msg)
 	case stateConfirm:
 		return m.handleConfirmKey(msg)
+	case stateCommitFailed:
+		return m.handleCommitFailedKey(msg)
+	case stateRestoreDraft:
+		return m.handleRestoreDraftKey(msg)
 	}
 
 	return m, nil
 }
 
+// canOpenEditor reports whether Ctrl+E may open the message in $EDITOR:
+// anywhere but while waiting for the AI or git.
+func (m Model) canOpenEditor() bool {
+	return !isAILoading(m.state) && m.state != stateDone
+}
+
+// openEditor suspends the TUI and opens the message composed so far in
+// git's editor, with the staged stat as comments.
+func (m Model) openEditor() (tea.Model, tea.Cmd) {
+	cmd, path, err := m.service.EditorCommand(context.Background(), m.draftMessage(), m.stat)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		message, readErr := app.ReadEditedMessage(path)
+		if err == nil {
+			err = readErr
+		}
+		return editorDoneMsg{message: message, err: err}
+	})
+}
+
+// draftMessage composes the message as it stands at the current step,
+// including what is typed but not yet confirmed.
+func (m Model) draftMessage() string {
+	c := m.conventionalCommit()
+	switch m.state {
+	case stateSelectMsg:
+		if candidate, ok := m.selectedCandidate(); ok {
+			c.Subject = candidate
+		}
+	case stateInputMsg:
+		c.Subject = m.input.Value()
+	case stateEditMsg:
+		edited := m.editedCommit()
+		c.Emoji, c.Type, c.Scope, c.Breaking, c.Subject = edited.Emoji, edited.Type, edited.Scope, edited.Breaking, edited.Subject
+	case stateInputBody:
+		c.Body = m.body.Value()
+	}
+	for _, t := range m.trailers {
+		if strings.TrimSpace(t.Value) != "" {
+			c.Footers = append(c.Footers, t)
+		}
+	}
+	return m.service.BuildMessage(c)
+}
+
+// handleEditorDone takes over the message saved in the editor and moves to
+// the confirm step. Conventional messages are split into their parts;
+// others are committed as written.
+func (m Model) handleEditorDone(msg editorDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = fmt.Errorf("editor: %w", msg.err)
+		return m, nil
+	}
+	if msg.message == "" {
+		m.err = errors.New("empty message, edit discarded")
+		return m, nil
+	}
+	m.err = nil
+	m.emoji, m.breakingNote, m.trailers = "", "", nil
+	c, err := commit.Parse(msg.message)
+	if err != nil {
+		header, body, _ := strings.Cut(msg.message, "\n")
+		m.commitType, m.scope, m.breaking = commit.AutoType, "", false
+		m.subject, m.bodyText = header, strings.Trim(body, "\n")
+		return m.enterConfirm(), nil
+	}
+	m.emoji, m.commitType, m.scope, m.breaking, m.subject, m.bodyText = c.Emoji, c.Type, c.Scope, c.Breaking, c.Subject, c.Body
+	for _, f := range c.Footers {
+		if f.IsBreaking() {
+			m.breaking, m.breakingNote = true, strings.TrimSpace(f.Value)
+			continue
+		}
+		m.trailers = append(m.trailers, f)
+	}
+	m.body.SetValue(m.bodyText)
+	return m.enterConfirm(), nil
+}
+
+// canShowDiff reports whether Tab opens the diff viewer in the current state:
+// the list and confirm screens, where Tab has no other meaning.
+func (m Model) canShowDiff() bool {
+	switch m.state {
+	case stateSelectType, stateSelectMsg, stateConfirm:
+		return true
+	}
+	return false
+}
+
+// matches is key.Matches for bindings that also work while typing. There
+// printable keys go to the text, so a binding set to a plain letter (e.g.
+// cx.ui.key.quit = q) only fires where nothing is typed.
+func (m Model) matches(msg tea.KeyMsg, b key.Binding) bool {
+	if msg.Type == tea.KeyRunes && m.typing() {
+		return false
+	}
+	return key.Matches(msg, b)
+}
+
+// typing reports whether the current screen takes text input.
+func (m Model) typing() bool {
+	switch m.state {
+	case stateInputScope, stateInputMsg, stateEditMsg, stateInputHint, stateInputBody, stateInputTrailer, stateInputBreaking:
+		return true
+	case stateSelectGitmoji:
+		return m.gitmojiList.FilterState() == list.Filtering
+	case stateSelectCoAuthor:
+		return m.coAuthorList.FilterState() == list.Filtering
+	}
+	return false
+}
+
+// canShowHelp reports whether the Help key opens the key bindings: on the
+// screens that take no text input.
+func (m Model) canShowHelp() bool {
+	switch m.state {
+	case stateSelectType, stateSelectMsg, stateSelectDetailMode, stateEditTrailers, stateConfirm, stateCommitFailed:
+		return true
+	}
+	return isAILoading(m.state)
+}
+
 func (m Model) handleSelectTypeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if msg.Type == tea.KeyEnter {
+	if key.Matches(msg, m.keys.Select) {
 		if i, ok := m.typeList.SelectedItem().(item); ok {
 			m.commitType = i.title
 			m.state = stateInputScope
-			m.input.Placeholder = "(optional) scope, press Enter to skip"
-			m.input.SetValue("")
+			m.input.Placeholder = scopePlaceholder
+			m.input.SetValue(m.scope)
 			m.input.Focus()
 		}
 		return m, nil
@@ -234,11 +576,15 @@ func (m Model) handleSelectTypeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleInputScopeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if msg.Type == tea.KeyEnter {
+	if m.matches(msg, m.keys.Select) {
 		m.scope = m.input.Value()
 		m.input.SetValue("")
-		m.state = stateAILoading
-		return m, m.startAIGeneration()
+		if len(m.candidates) > 0 && m.candidatesFor == m.candidatesKey() {
+			// Back and forth without changes: keep the candidates.
+			m.state = stateSelectMsg
+			return m, nil
+		}
+		return m.startAI(stateAILoading, m.generateAI)
 	}
 	var cmd tea.Cmd
 	m.input, cmd = m.input.Update(msg)
@@ -246,7 +592,13 @@ func (m Model) handleInputScopeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleSelectMsgKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if msg.Type == tea.KeyEnter {
+	if key.Matches(msg, m.keys.Edit) {
+		if candidate, ok := m.selectedCandidate(); ok {
+			return m.startEditMsg(candidate)
+		}
+		return m, nil
+	}
+	if key.Matches(msg, m.keys.Select) {
 		if i, ok := m.msgList.SelectedItem().(item); ok {
 			switch i.title {
 			case "[Manual entry]":
@@ -255,13 +607,17 @@ func (m Model) handleSelectMsgKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.input.SetValue("")
 				m.input.Focus()
 			case "[Regenerate]":
-				m.state = stateAILoading
-				return m, m.startAIGeneration()
+				return m.startAI(stateAILoading, m.generateAI)
+			case "[Regenerate with hint…]":
+				m.state = stateInputHint
+				m.input.Placeholder = "e.g. shorter, focus on the API change, mention the migration"
+				m.input.SetValue("")
+				return m, m.input.Focus()
 			default:
 				m.err = nil
 				m.subject = i.title
-				m.state = stateSelectDetailMode
-				m.detailList.Select(1)
+				m.syncBreakingFromHeader()
+				return m.afterSubject(), nil
 			}
 		}
 		return m, nil
@@ -271,50 +627,202 @@ func (m Model) handleSelectMsgKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// selectedCandidate returns the highlighted AI candidate; false when one of
+// the actions below the candidates is highlighted.
+func (m Model) selectedCandidate() (string, bool) {
+	i, ok := m.msgList.SelectedItem().(item)
+	if !ok || !slices.Contains(m.candidates, i.title) {
+		return "", false
+	}
+	return i.title, true
+}
+
+// handleInputHintKey asks for new candidates steered by the typed hint and
+// away from the current ones.
+func (m Model) handleInputHintKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case m.matches(msg, m.keys.Back):
+		m.state = stateSelectMsg
+		return m, nil
+	case m.matches(msg, m.keys.Select):
+		hint := strings.TrimSpace(m.input.Value())
+		rejected := m.candidates
+		m.input.SetValue("")
+		return m.startAI(stateAILoading, func(ctx context.Context) tea.Cmd {
+			return m.regenerateAI(ctx, hint, rejected)
+		})
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
 func (m Model) handleInputMsgKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if msg.Type == tea.KeyCtrlR {
+	if m.matches(msg, m.keys.RetryAI) {
 		m.err = nil
-		m.state = stateAILoading
-		return m, m.startAIGeneration()
+		return m.startAI(stateAILoading, m.generateAI)
 	}
-	if msg.Type == tea.KeyEnter {
+	if m.matches(msg, m.keys.Select) {
 		m.err = nil
 		m.subject = m.input.Value()
+		m.syncBreakingFromHeader()
 		m.input.SetValue("")
+		return m.afterSubject(), nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// Fields of the candidate editor.
+const (
+	editType = iota
+	editScope
+	editSubject
+)
+
+// startEditMsg opens candidate in the editor, split into type, scope and
+// subject. Candidates that are not a full header (the type was picked up
+// front) keep the chosen type and scope.
+func (m Model) startEditMsg(candidate string) (tea.Model, tea.Cmd) {
+	h, err := commit.ParseHeader(candidate)
+	if err != nil {
+		h = commit.Header{Type: m.commitTypeForMessage(), Scope: m.scope, Breaking: m.breaking, Subject: candidate}
+	}
+	m.editEmoji = h.Emoji
+	typ := h.Type
+	if h.Breaking {
+		typ += "!"
+	}
+	for i, f := range []struct{ prompt, value string }{
+		{"type:    ", typ},
+		{"scope:   ", h.Scope},
+		{"subject: ", h.Subject},
+	} {
+		in := textinput.New()
+		in.Prompt = f.prompt
+		in.SetValue(f.value)
+		m.editFields[i] = in
+	}
+	m.err = nil
+	m.state = stateEditMsg
+	return m, m.focusEditField(editSubject)
+}
+
+func (m *Model) focusEditField(i int) tea.Cmd {
+	m.editFocus = (i + len(m.editFields)) % len(m.editFields)
+	for j := range m.editFields {
+		m.editFields[j].Blur()
+	}
+	return m.editFields[m.editFocus].Focus()
+}
+
+// editedCommit returns the header being edited, with the candidate's
+// gitmoji; a trailing "!" on the type marks it breaking.
+func (m Model) editedCommit() *commit.ConventionalCommit {
+	typ, breaking := strings.CutSuffix(strings.TrimSpace(m.editFields[editType].Value()), "!")
+	return &commit.ConventionalCommit{
+		Emoji:    m.editEmoji,
+		Type:     typ,
+		Scope:    strings.TrimSpace(m.editFields[editScope].Value()),
+		Breaking: breaking,
+		Subject:  strings.TrimSpace(m.editFields[editSubject].Value()),
+	}
+}
+
+func (m Model) handleEditMsgKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case m.matches(msg, m.keys.Back):
+		m.state = stateSelectMsg
+		return m, nil
+	case m.matches(msg, m.keys.NextField):
+		return m, m.focusEditField(m.editFocus + 1)
+	case m.matches(msg, m.keys.PrevField):
+		return m, m.focusEditField(m.editFocus - 1)
+	case m.matches(msg, m.keys.Select):
+		c := m.editedCommit()
+		if commit.HasErrors(m.service.CheckHeader(c)) {
+			return m, nil
+		}
+		m.commitType, m.scope, m.breaking, m.subject = c.Type, c.Scope, c.Breaking, c.Subject
+		m.emoji = c.Emoji
+		return m.afterSubject(), nil
+	}
+	var cmd tea.Cmd
+	m.editFields[m.editFocus], cmd = m.editFields[m.editFocus].Update(msg)
+	return m, cmd
+}
+
+// afterSubject moves on from the subject step: to the gitmoji list in
+// gitmoji mode, otherwise to the detail mode list.
+func (m Model) afterSubject() Model {
+	if !m.service.Gitmoji() {
+		m.state = stateSelectDetailMode
+		m.detailList.Select(1)
+		return m
+	}
+	// The emoji is kept apart from the header so the configured placement applies.
+	suggested, subject := commit.CutEmoji(m.subject)
+	m.subject = subject
+	if suggested == "" {
+		suggested = m.emoji
+	}
+	if suggested == "" {
+		suggested = m.service.TypeEmoji(m.commitTypeForMessage())
+	}
+	want, _ := commit.LookupGitmoji(suggested)
+	items := make([]list.Item, len(commit.Gitmojis))
+	selected := 0
+	for i, g := range commit.Gitmojis {
+		items[i] = gitmojiItem{g}
+		if g.Code == want.Code {
+			selected = i
+		}
+	}
+	m.gitmojiList = m.keys.listKeys(list.New(items, list.NewDefaultDelegate(), m.width, m.height-4))
+	m.gitmojiList.Title = "Select gitmoji"
+	m.gitmojiList.SetShowStatusBar(false)
+	m.gitmojiList.Select(selected)
+	m.state = stateSelectGitmoji
+	return m
+}
+
+func (m Model) handleSelectGitmojiKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.gitmojiList.FilterState() != list.Filtering && key.Matches(msg, m.keys.Select) {
+		if i, ok := m.gitmojiList.SelectedItem().(gitmojiItem); ok {
+			m.emoji = i.Emoji
+		}
 		m.state = stateSelectDetailMode
 		m.detailList.Select(1)
 		return m, nil
 	}
 	var cmd tea.Cmd
-	m.input, cmd = m.input.Update(msg)
+	m.gitmojiList, cmd = m.gitmojiList.Update(msg)
 	return m, cmd
 }
 
 func (m Model) handleSelectDetailModeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if msg.Type == tea.KeyCtrlR {
+	if key.Matches(msg, m.keys.RetryAI) {
 		m.err = nil
-		m.state = stateDetailAILoading
-		return m, m.startAIDetailGeneration()
+		return m.startAI(stateDetailAILoading, m.generateAIDetail)
 	}
-	if msg.Type == tea.KeyEnter {
+	if key.Matches(msg, m.keys.Select) {
 		if i, ok := m.detailList.SelectedItem().(item); ok {
 			switch i.title {
 			case "[Skip]":
 				m.err = nil
 				m.bodyText = ""
-				m.footer = ""
-				m.state = stateConfirm
-				return m, nil
+				return m.enterConfirm(), nil
 			case "[Generate with AI]":
 				m.err = nil
-				m.state = stateDetailAILoading
-				return m, m.startAIDetailGeneration()
+				if m.aiDetailFor == m.detailKey() {
+					return m.handleAIDetailResult(m.aiDetail)
+				}
+				return m.startAI(stateDetailAILoading, m.generateAIDetail)
 			default:
 				m.err = nil
-				m.bodyText = ""
-				m.footer = ""
 				m.state = stateInputBody
-				m.body.SetValue("")
+				m.body.SetValue(m.bodyText)
 				m.body.Focus()
 			}
 		}
@@ -326,20 +834,9 @@ func (m Model) handleSelectDetailModeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleInputBodyKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if msg.Type == tea.KeyEsc {
-		m.bodyText = ""
-		m.state = stateInputFooter
-		m.input.Placeholder = "(optional) footer, press Enter to skip"
-		m.input.SetValue(m.footer)
-		m.input.Focus()
-		return m, nil
-	}
-	if msg.Type == tea.KeyTab {
+	if m.matches(msg, m.keys.Done) {
 		m.bodyText = m.body.Value()
-		m.state = stateInputFooter
-		m.input.Placeholder = "(optional) footer, press Enter to skip"
-		m.input.SetValue(m.footer)
-		m.input.Focus()
+		m.state = stateEditTrailers
 		return m, nil
 	}
 	var cmd tea.Cmd
@@ -347,11 +844,91 @@ func (m Model) handleInputBodyKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m Model) handleInputFooterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if msg.Type == tea.KeyEnter {
-		m.footer = m.input.Value()
-		m.input.SetValue("")
-		m.state = stateConfirm
+func (m Model) handleEditTrailersKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		if m.trailerCursor > 0 {
+			m.trailerCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.trailerCursor < len(m.trailers)-1 {
+			m.trailerCursor++
+		}
+	case key.Matches(msg, m.keys.AddTrailer):
+		return m.inputTrailer(-1), nil
+	case key.Matches(msg, m.keys.CoAuthor):
+		m.err = nil
+		return m, m.loadCoAuthors()
+	case key.Matches(msg, m.keys.EditTrailer):
+		if len(m.trailers) > 0 {
+			return m.inputTrailer(m.trailerCursor), nil
+		}
+	case key.Matches(msg, m.keys.DeleteTrailer):
+		if len(m.trailers) > 0 {
+			m.trailers = slices.Delete(slices.Clone(m.trailers), m.trailerCursor, m.trailerCursor+1)
+			if m.trailerCursor > 0 && m.trailerCursor >= len(m.trailers) {
+				m.trailerCursor--
+			}
+		}
+	case key.Matches(msg, m.keys.Select):
+		return m.enterConfirm(), nil
+	}
+	return m, nil
+}
+
+// inputTrailer opens the trailer input for row idx, or for a new row when idx is -1.
+func (m Model) inputTrailer(idx int) Model {
+	m.trailerEdit = idx
+	m.state = stateInputTrailer
+	m.input.Placeholder = "Token: value (e.g. Refs: #123)"
+	m.input.SetValue("")
+	if idx >= 0 {
+		t := m.trailers[idx]
+		m.input.SetValue(t.Token + t.Separator + t.Value)
+	}
+	m.input.Focus()
+	return m
+}
+
+func (m Model) handleInputTrailerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case m.matches(msg, m.keys.Back):
+		m.err = nil
+		m.state = stateEditTrailers
+		return m, nil
+	case m.matches(msg, m.keys.Select):
+		value := strings.TrimSpace(m.input.Value())
+		if value == "" {
+			m.err = nil
+			m.state = stateEditTrailers
+			return m, nil
+		}
+		footers, ok := commit.ParseFooters(value)
+		if !ok || len(footers) != 1 {
+			m.err = fmt.Errorf("trailer must look like \"Token: value\" or \"Token #value\"")
+			return m, nil
+		}
+		m.err = nil
+		m.state = stateEditTrailers
+		trailers := slices.Clone(m.trailers)
+		if m.trailerEdit >= 0 {
+			trailers = slices.Delete(trailers, m.trailerEdit, m.trailerEdit+1)
+		}
+		if footers[0].IsBreaking() {
+			// Breaking changes have their own step so flag and footer agree.
+			m.breaking = true
+			m.breakingNote = strings.TrimSpace(footers[0].Value)
+			m.trailers = trailers
+			m.trailerCursor = min(m.trailerCursor, max(len(trailers)-1, 0))
+			return m, nil
+		}
+		if m.trailerEdit >= 0 {
+			trailers = slices.Insert(trailers, m.trailerEdit, footers[0])
+		} else {
+			trailers = append(trailers, footers[0])
+			m.trailerCursor = len(trailers) - 1
+		}
+		m.trailers = trailers
 		return m, nil
 	}
 	var cmd tea.Cmd
@@ -359,29 +936,159 @@ func (m Model) handleInputFooterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m Model) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	if msg.Type == tea.KeyEsc {
-		m.state = stateInputFooter
-		m.input.Placeholder = "(optional) footer, press Enter to skip"
-		m.input.SetValue(m.footer)
-		m.input.Focus()
+func (m Model) handleCoAuthors(msg coAuthorsMsg) (tea.Model, tea.Cmd) {
+	if len(msg.coAuthors) == 0 {
+		m.err = msg.err
+		if m.err == nil {
+			m.err = errors.New("no co-author suggestions; add some with 'git config --add cx.coauthors \"Name <email>\"'")
+		}
 		return m, nil
 	}
-	switch msg.String() {
-	case "y", "Y":
+	items := make([]list.Item, len(msg.coAuthors))
+	for i, c := range msg.coAuthors {
+		desc := "recently touched these files"
+		if c.Configured {
+			desc = "from cx.coauthors"
+		}
+		if slices.Contains(m.trailers, coAuthorTrailer(c.Ident)) {
+			desc = "already added"
+		}
+		items[i] = item{title: c.Ident, desc: desc}
+	}
+	m.coAuthorList = m.keys.listKeys(list.New(items, list.NewDefaultDelegate(), m.width, m.height-4))
+	m.coAuthorList.Title = "Select co-author"
+	m.coAuthorList.SetShowStatusBar(false)
+	m.err = nil
+	m.state = stateSelectCoAuthor
+	return m, nil
+}
+
+func (m Model) handleSelectCoAuthorKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.coAuthorList.FilterState() != list.Filtering {
+		if key.Matches(msg, m.keys.Back) && m.coAuthorList.FilterState() == list.Unfiltered {
+			m.state = stateEditTrailers
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.Select) {
+			if i, ok := m.coAuthorList.SelectedItem().(item); ok {
+				trailer := coAuthorTrailer(i.title)
+				if idx := slices.Index(m.trailers, trailer); idx >= 0 {
+					m.trailerCursor = idx
+				} else {
+					m.trailers = append(slices.Clone(m.trailers), trailer)
+					m.trailerCursor = len(m.trailers) - 1
+				}
+			}
+			m.state = stateEditTrailers
+			return m, nil
+		}
+	}
+	var cmd tea.Cmd
+	m.coAuthorList, cmd = m.coAuthorList.Update(msg)
+	return m, cmd
+}
+
+func coAuthorTrailer(ident string) commit.Footer {
+	return commit.Footer{Token: "Co-authored-by", Separator: ": ", Value: ident}
+}
+
+// enterConfirm shows the final message, with trailers applied by git.
+func (m Model) enterConfirm() Model {
+	m.state = stateConfirm
+	m.err = nil
+	m.preview, m.previewErr = m.service.FinalMessage(context.Background(), m.conventionalCommit(), m.trailers)
+	return m
+}
+
+func (m Model) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Breaking):
+		return m.toggleBreaking()
+	case key.Matches(msg, m.keys.Commit):
 		m.state = stateDone
 		return m, m.doCommit()
-	case "n", "N":
+	case key.Matches(msg, m.keys.Abort):
 		m.quitting = true
 		return m, tea.Quit
 	}
-	if msg.Type == tea.KeyEnter {
-		m.state = stateDone
-		return m, m.doCommit()
+	return m, nil
+}
+
+// handleCommitFailedKey offers to retry a failed git commit, e.g. after
+// fixing what a pre-commit hook complained about in another terminal.
+func (m Model) handleCommitFailedKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back):
+		m.err = nil
+		return m.enterConfirm(), nil
+	case key.Matches(msg, m.keys.Retry):
+		return m.retryCommit()
+	case key.Matches(msg, m.keys.NoVerify):
+		opts := m.service.CommitOptions()
+		opts.NoVerify = true
+		m.service.SetCommitOptions(opts)
+		return m.retryCommit()
+	case key.Matches(msg, m.keys.Abort):
+		m.quitting = true
+		return m, tea.Quit
 	}
 	return m, nil
 }
 
+func (m Model) retryCommit() (tea.Model, tea.Cmd) {
+	m.err = nil
+	m.logOutput = ""
+	m.state = stateDone
+	return m, m.doCommit()
+}
+
+// toggleBreaking turns the breaking-change flag off, or turns it on and asks
+// for the BREAKING CHANGE note, pre-filled by the AI when there is none yet.
+func (m Model) toggleBreaking() (tea.Model, tea.Cmd) {
+	if m.breaking {
+		m.breaking = false
+		m.breakingNote = ""
+		m.subject = commit.MarkBreaking(m.subject, false)
+		return m.enterConfirm(), nil
+	}
+	m.breaking = true
+	m.body.SetValue(m.breakingNote)
+	if m.breakingNote == "" {
+		return m.startAI(stateBreakingAILoading, m.generateAIBreaking)
+	}
+	m.state = stateInputBreaking
+	m.body.Focus()
+	return m, nil
+}
+
+func (m Model) handleInputBreakingKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.matches(msg, m.keys.RetryAI) {
+		m.err = nil
+		return m.startAI(stateBreakingAILoading, m.generateAIBreaking)
+	}
+	switch {
+	case m.matches(msg, m.keys.Back):
+		m.err = nil
+		m.breakingNote = ""
+		return m.enterConfirm(), nil
+	case m.matches(msg, m.keys.Done):
+		m.err = nil
+		m.breakingNote = strings.TrimSpace(m.body.Value())
+		return m.enterConfirm(), nil
+	}
+	var cmd tea.Cmd
+	m.body, cmd = m.body.Update(msg)
+	return m, cmd
+}
+
+// syncBreakingFromHeader follows the "!" of a subject entered as a full
+// Conventional header.
+func (m *Model) syncBreakingFromHeader() {
+	if h, err := commit.ParseHeader(m.subject); err == nil {
+		m.breaking = h.Breaking
+	}
+}
+
 func (m Model) handleAIResult(msg aiResultMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil || len(msg.candidates) == 0 {
 		m.err = msg.err
@@ -393,18 +1100,25 @@ func (m Model) handleAIResult(msg aiResultMsg) (tea.Model, tea.Cmd) {
 	}
 
 	m.candidates = msg.candidates
-	items := make([]list.Item, 0, len(msg.candidates)+2)
-	for _, c := range msg.candidates {
-		items = append(items, item{title: c})
+	m.rationales = msg.rationales
+	m.candidatesFor = m.candidatesKey()
+	items := make([]list.Item, 0, len(msg.candidates)+3)
+	for i, c := range msg.candidates {
+		var rationale string
+		if i < len(msg.rationales) {
+			rationale = msg.rationales[i]
+		}
+		items = append(items, item{title: c, desc: rationale})
 	}
 	manualDesc := "Enter a commit message manually"
-	if m.commitType == "auto" {
+	if m.commitType == commit.AutoType {
 		manualDesc = "Enter a Conventional header manually"
 	}
 	items = append(items, item{title: "[Manual entry]", desc: manualDesc})
 	items = append(items, item{title: "[Regenerate]", desc: "Regenerate with AI"})
+	items = append(items, item{title: "[Regenerate with hint…]", desc: "Tell the AI what to change, e.g. shorter or focus on the API"})
 
-	m.msgList = list.New(items, list.NewDefaultDelegate(), m.width, m.height-4)
+	m.msgList = m.keys.listKeys(list.New(items, list.NewDefaultDelegate(), m.width, m.height-4))
 	m.msgList.Title = "Select commit message"
 	m.msgList.SetShowStatusBar(false)
 	m.msgList.SetFilteringEnabled(false)
@@ -421,8 +1135,60 @@ func (m Model) handleAIDetailResult(msg aiDetailResultMsg) (tea.Model, tea.Cmd)
 	}
 
 	m.err = nil
+	// Trailers the user added (e.g. co-authors) stay; those the previous AI
+	// answer added are replaced by this one's.
+	previous, _ := commit.ParseFooters(m.aiDetail.footer)
+	trailers := slices.DeleteFunc(slices.Clone(m.trailers), func(f commit.Footer) bool {
+		return slices.Contains(previous, f) && !slices.Contains(m.defaultTrailers, f)
+	})
+	m.aiDetail, m.aiDetailFor = msg, m.detailKey()
+	m.bodyText = msg.body
+	footers, ok := commit.ParseFooters(msg.footer)
+	if !ok {
+		// Not a trailer block; keep the text in the body rather than drop it.
+		m.bodyText = strings.TrimSpace(m.bodyText + "\n\n" + msg.footer)
+	}
+	for _, f := range footers {
+		if f.IsBreaking() {
+			// Keep the BREAKING CHANGE note in its own step so flag and footer agree.
+			m.breaking = true
+			m.breakingNote = strings.TrimSpace(f.Value)
+			continue
+		}
+		if !slices.Contains(trailers, f) {
+			trailers = append(trailers, f)
+		}
+	}
+	m.trailers = trailers
+	m.trailerCursor = 0
+	m.body.SetValue(m.bodyText)
+	m.state = stateInputBody
+	m.body.Focus()
+	return m, nil
+}
+
+func (m Model) handleAIBreakingResult(msg aiBreakingResultMsg) (tea.Model, tea.Cmd) {
+	m.err = msg.err
+	if msg.err == nil {
+		m.breakingNote = msg.note
+	}
+	m.body.SetValue(m.breakingNote)
+	m.state = stateInputBreaking
+	m.body.Focus()
+	return m, nil
+}
+
+func (m Model) handleOperationResult(msg operationResultMsg) (tea.Model, tea.Cmd) {
+	m.err = msg.err
+	if msg.subject == "" {
+		m.state = stateInputMsg
+		m.input.Placeholder = m.subjectPlaceholder()
+		m.input.SetValue("")
+		m.input.Focus()
+		return m, nil
+	}
+	m.subject = msg.subject
 	m.bodyText = msg.body
-	m.footer = msg.footer
 	m.body.SetValue(msg.body)
 	m.state = stateInputBody
 	m.body.Focus()
@@ -434,76 +1200,193 @@ func (m Model) updateChildren(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.state {
 	case stateSelectType:
 		m.typeList, cmd = m.typeList.Update(msg)
-	case stateInputScope, stateInputMsg, stateInputFooter:
+	case stateInputScope, stateInputMsg, stateInputHint, stateInputTrailer:
 		m.input, cmd = m.input.Update(msg)
-	case stateInputBody:
+	case stateEditMsg:
+		m.editFields[m.editFocus], cmd = m.editFields[m.editFocus].Update(msg)
+	case stateInputBody, stateInputBreaking:
 		m.body, cmd = m.body.Update(msg)
 	case stateSelectMsg:
 		m.msgList, cmd = m.msgList.Update(msg)
+	case stateSelectGitmoji:
+		m.gitmojiList, cmd = m.gitmojiList.Update(msg)
 	case stateSelectDetailMode:
 		m.detailList, cmd = m.detailList.Update(msg)
+	case stateSelectCoAuthor:
+		m.coAuthorList, cmd = m.coAuthorList.Update(msg)
 	}
 	return m, cmd
 }
 
-func (m Model) startAIGeneration() tea.Cmd {
-	return tea.Batch(m.spin.Tick, m.generateAI())
+// isAILoading reports whether s waits for an AI request.
+func isAILoading(s State) bool {
+	switch s {
+	case stateAILoading, stateDetailAILoading, stateBreakingAILoading:
+		return true
+	}
+	return false
 }
 
-func (m Model) startAIDetailGeneration() tea.Cmd {
-	return tea.Batch(m.spin.Tick, m.generateAIDetail())
+// startAI moves to the loading screen state and runs the request built by
+// generate under a context that Esc and Ctrl+C cancel. CLI providers run in
+// their own process group so that cancelling kills them with their children.
+// The result of a cancelled request is dropped.
+func (m Model) startAI(state State, generate func(context.Context) tea.Cmd) (Model, tea.Cmd) {
+	ctx, cancel := context.WithCancel(execx.WithProcessGroup(context.Background()))
+	done := make(chan struct{})
+	m.state = state
+	m.cancelAI = cancel
+	m.aiDone = done
+	m.aiStarted = time.Now()
+	run := generate(ctx)
+	return m, tea.Batch(m.spin.Tick, func() tea.Msg {
+		defer close(done)
+		defer cancel()
+		msg := run()
+		if ctx.Err() != nil {
+			return nil
+		}
+		return msg
+	})
 }
 
-func (m Model) generateAI() tea.Cmd {
+// stopAI cancels the running AI request and returns a command that waits
+// until it has returned.
+func (m Model) stopAI() tea.Cmd {
+	if m.cancelAI == nil {
+		return nil
+	}
+	m.cancelAI()
+	done := m.aiDone
 	return func() tea.Msg {
-		commitType := m.commitType
-		if commitType == "auto" {
-			commitType = ""
+		<-done
+		return nil
+	}
+}
+
+// cancelAIRequest cancels the running AI request and returns to where it was
+// started from; with nothing to go back to (a merge, revert or cherry-pick)
+// the message is typed by hand instead.
+func (m Model) cancelAIRequest() (tea.Model, tea.Cmd) {
+	m.stopAI()
+	m.cancelAI = nil
+	m.err = nil
+	if m.state == stateBreakingAILoading {
+		m.body.SetValue(m.breakingNote)
+		m.state = stateInputBreaking
+		return m, m.body.Focus()
+	}
+	if len(m.history) > 0 {
+		return m.back()
+	}
+	m.state = stateInputMsg
+	m.input.Placeholder = m.subjectPlaceholder()
+	m.input.SetValue("")
+	return m, m.input.Focus()
+}
+
+// aiElapsed renders how long the running AI request has taken so far.
+func (m Model) aiElapsed() string {
+	if m.aiStarted.IsZero() {
+		return ""
+	}
+	return dimStyle.Render(fmt.Sprintf("(%s)", time.Since(m.aiStarted).Truncate(time.Second)))
+}
+
+func (m Model) generateAI(ctx context.Context) tea.Cmd {
+	return m.regenerateAI(ctx, "", nil)
+}
+
+// regenerateAI asks for candidates steered by hint and unlike rejected.
+func (m Model) regenerateAI(ctx context.Context, hint string, rejected []string) tea.Cmd {
+	return func() tea.Msg {
+		candidates, err := m.service.RegenerateCandidates(ctx, m.diff, m.stat, m.commitTypeForMessage(), m.scope, hint, rejected)
+		msg := aiResultMsg{err: err}
+		for _, c := range candidates {
+			msg.candidates = append(msg.candidates, c.Header)
+			msg.rationales = append(msg.rationales, c.Rationale)
 		}
-		candidates, err := m.service.GenerateCandidates(context.Background(), m.diff, m.stat, commitType, m.scope)
-		return aiResultMsg{candidates: candidates, err: err}
+		return msg
 	}
 }
 
-func (m Model) generateAIDetail() tea.Cmd {
+func (m Model) generateAIDetail(ctx context.Context) tea.Cmd {
 	return func() tea.Msg {
 		commitType := m.commitType
-		if commitType == "auto" {
+		if commitType == commit.AutoType {
 			commitType = ""
 		}
-		body, footer, err := m.service.GenerateDetails(context.Background(), m.diff, m.stat, commitType, m.scope, m.subject)
+		body, footer, err := m.service.GenerateDetails(ctx, m.diff, m.stat, commitType, m.scope, m.subject)
 		return aiDetailResultMsg{body: body, footer: footer, err: err}
 	}
 }
 
+func (m Model) loadCoAuthors() tea.Cmd {
+	return func() tea.Msg {
+		coAuthors, err := m.service.CoAuthors(context.Background())
+		return coAuthorsMsg{coAuthors: coAuthors, err: err}
+	}
+}
+
+func (m Model) generateAIBreaking(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		note, err := m.service.GenerateBreakingNote(ctx, m.diff, m.stat, m.commitTypeForMessage(), m.scope, m.subject)
+		return aiBreakingResultMsg{note: note, err: err}
+	}
+}
+
+func (m Model) generateOperation(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		subject, body, err := m.service.OperationMessage(ctx, m.operation, m.stat)
+		return operationResultMsg{subject: subject, body: body, err: err}
+	}
+}
+
 func (m Model) subjectPlaceholder() string {
-	if m.commitType == "auto" {
+	if m.commitType == commit.AutoType {
 		return "commit subject (Conventional header)"
 	}
 	return "commit subject"
 }
 
 func (m Model) commitTypeForMessage() string {
-	if m.commitType == "auto" {
+	if m.commitType == commit.AutoType {
 		return ""
 	}
 	return m.commitType
 }
 
+// conventionalCommit assembles the message being built, adding the
+// BREAKING CHANGE footer when a note was given.
+func (m Model) conventionalCommit() *commit.ConventionalCommit {
+	c := &commit.ConventionalCommit{
+		Emoji:    m.emoji,
+		Type:     m.commitTypeForMessage(),
+		Scope:    m.scope,
+		Breaking: m.breaking,
+		Subject:  m.subject,
+		Body:     m.bodyText,
+	}
+	if m.breaking && m.breakingNote != "" {
+		c.Footers = []commit.Footer{{Token: "BREAKING CHANGE", Separator: ": ", Value: m.breakingNote}}
+	}
+	return c
+}
+
 func (m Model) doCommit() tea.Cmd {
 	return func() tea.Msg {
-		c := &commit.ConventionalCommit{
-			Type:    m.commitTypeForMessage(),
-			Scope:   m.scope,
-			Subject: m.subject,
-			Body:    m.bodyText,
-			Footer:  m.footer,
-		}
-		msg := m.service.BuildMessage(c)
+		// On a trailer error FinalMessage falls back to plain footers, as previewed.
+		msg, _ := m.service.FinalMessage(context.Background(), m.conventionalCommit(), m.trailers)
 		if m.dryRun {
 			return commitDoneMsg{message: msg}
 		}
 		out, err := m.service.Commit(context.Background(), msg)
+		// With a message file git has yet to commit, and may not (an emptied
+		// message, a failing commit-msg hook); LoadDraft drops the draft once
+		// HEAD moves.
+		if err == nil && m.drafts && m.service.MessageFile() == "" {
+			_ = m.service.ClearDraft(context.Background())
+		}
 		return commitDoneMsg{output: out, err: err}
 	}
 }
@@ -521,14 +1404,23 @@ func (m Model) View() string {
 					previewStyle.Render(m.dryRunMsg),
 				)
 			}
+			if m.service.MessageFile() != "" {
+				return selectedStyle.Render("Commit message prepared.\n")
+			}
 			return selectedStyle.Render("Committed successfully!\n")
 		}
 		return dimStyle.Render("Aborted.\n")
 	}
+	if m.showDiff {
+		return m.diffView.View()
+	}
+	if m.showHelp {
+		return m.keys.helpView(m.width)
+	}
 
 	switch m.state {
 	case stateSelectType:
-		return m.typeList.View()
+		return m.typeList.View() + "\n" + helpStyle.Render(m.keys.help(m.keys.Diff, m.keys.Help))
 	case stateInputScope:
 		return m.viewInputScope()
 	case stateAILoading:
@@ -537,20 +1429,44 @@ func (m Model) View() string {
 		return m.viewSelectMsg()
 	case stateInputMsg:
 		return m.viewInputMsg()
+	case stateEditMsg:
+		return m.viewEditMsg()
+	case stateInputHint:
+		return m.viewInputHint()
+	case stateSelectGitmoji:
+		return m.gitmojiList.View() + "\n" + helpStyle.Render(m.keys.help(m.keys.Select)+" • / to filter • "+m.keys.help(m.keys.Back, m.keys.Quit))
 	case stateSelectDetailMode:
 		return m.viewSelectDetailMode()
 	case stateDetailAILoading:
 		return fmt.Sprintf(
-			"\n  %s Generating commit details...\n\n%s",
+			"\n  %s Generating commit details... %s\n\n%s",
 			m.spin.View(),
-			helpStyle.Render("Ctrl+C to quit"),
+			m.aiElapsed(),
+			m.loadingHelp(),
 		)
 	case stateInputBody:
 		return m.viewInputBody()
-	case stateInputFooter:
-		return m.viewInputFooter()
+	case stateEditTrailers:
+		return m.viewEditTrailers()
+	case stateInputTrailer:
+		return m.viewInputTrailer()
+	case stateSelectCoAuthor:
+		return m.coAuthorList.View() + "\n" + helpStyle.Render(m.keys.help(withDesc(m.keys.Select, "add"))+" • / to filter • "+m.keys.help(m.keys.Back, m.keys.Quit))
+	case stateInputBreaking:
+		return m.viewInputBreaking()
+	case stateBreakingAILoading:
+		return fmt.Sprintf(
+			"\n  %s Looking for breaking changes... %s\n\n%s",
+			m.spin.View(),
+			m.aiElapsed(),
+			m.loadingHelp(),
+		)
 	case stateConfirm:
 		return m.viewConfirm()
+	case stateCommitFailed:
+		return m.viewCommitFailed()
+	case stateRestoreDraft:
+		return m.viewRestoreDraft()
 	case stateDone:
 		return selectedStyle.Render("Committing...\n")
 	}
@@ -563,24 +1479,35 @@ func (m Model) viewInputScope() string {
 		"%s\n\n%s\n\n%s",
 		titleStyle.Render("Enter scope"),
 		m.input.View(),
-		helpStyle.Render("Enter to confirm • Ctrl+C to quit"),
+		helpStyle.Render(m.keys.help(withDesc(m.keys.Select, "confirm"), m.keys.Back, m.keys.Quit)),
 	)
 }
 
 func (m Model) viewAILoading() string {
+	what := "commit messages"
+	if m.operation.Kind != git.OperationNone {
+		what = string(m.operation.Kind) + " commit message"
+	}
 	return fmt.Sprintf(
-		"\n  %s Generating commit messages...\n\n%s",
+		"\n  %s Generating %s... %s\n\n%s",
 		m.spin.View(),
-		helpStyle.Render("Ctrl+C to quit"),
+		what,
+		m.aiElapsed(),
+		m.loadingHelp(),
 	)
 }
 
+// loadingHelp is the help line of the screens waiting for the AI provider.
+func (m Model) loadingHelp() string {
+	return helpStyle.Render(m.keys.help(withDesc(m.keys.Back, "cancel"), m.keys.Quit, m.keys.Help))
+}
+
 func (m Model) viewSelectMsg() string {
 	view := m.msgList.View()
 	if m.err != nil {
 		view = errorStyle.Render(fmt.Sprintf("AI error: %v\n\n", m.err)) + view
 	}
-	return view + "\n" + helpStyle.Render("Enter to select • Ctrl+C to quit")
+	return view + "\n" + helpStyle.Render(m.keys.help(m.keys.Select, m.keys.Edit, m.keys.Diff, m.keys.Back, m.keys.Quit, m.keys.Help))
 }
 
 func (m Model) viewInputMsg() string {
@@ -593,7 +1520,47 @@ func (m Model) viewInputMsg() string {
 		errMsg,
 		titleStyle.Render("Enter commit message"),
 		m.input.View(),
-		helpStyle.Render("Enter to confirm • Ctrl+R to retry AI • Ctrl+C to quit"),
+		helpStyle.Render(m.keys.help(withDesc(m.keys.Select, "confirm"), m.keys.RetryAI, m.keys.Back, m.keys.Quit)),
+	)
+}
+
+func (m Model) viewInputHint() string {
+	return fmt.Sprintf(
+		"%s\n\n%s\n\n%s",
+		titleStyle.Render("How should the new suggestions differ?"),
+		m.input.View(),
+		helpStyle.Render(m.keys.help(withDesc(m.keys.Select, "regenerate"), m.keys.Back, m.keys.Quit)),
+	)
+}
+
+// viewEditMsg shows the candidate editor with the lint result of the header
+// as typed.
+func (m Model) viewEditMsg() string {
+	var fields strings.Builder
+	for _, f := range m.editFields {
+		fields.WriteString(f.View() + "\n")
+	}
+	c := m.editedCommit()
+	header := m.service.BuildMessage(c)
+	status := selectedStyle.Render("✓ header is valid")
+	if issues := m.service.CheckHeader(c); len(issues) > 0 {
+		var lines []string
+		for _, issue := range issues {
+			style := dimStyle
+			if issue.Level == commit.LevelError {
+				style = errorStyle
+			}
+			lines = append(lines, style.Render(fmt.Sprintf("%s: %s (%s)", issue.Level, issue.Message, issue.Rule)))
+		}
+		status = strings.Join(lines, "\n")
+	}
+	return fmt.Sprintf(
+		"%s\n\n%s\n%s\n\n%s\n\n%s",
+		titleStyle.Render("Edit commit message"),
+		fields.String(),
+		previewStyle.Render(header),
+		status,
+		helpStyle.Render(m.keys.help(m.keys.NextField, m.keys.PrevField, withDesc(m.keys.Select, "accept"), m.keys.Back, m.keys.Quit)),
 	)
 }
 
@@ -602,10 +1569,11 @@ func (m Model) viewSelectDetailMode() string {
 	if m.err != nil {
 		view = errorStyle.Render(fmt.Sprintf("AI error: %v\n\n", m.err)) + view
 	}
-	return view + "\n" + helpStyle.Render("Ctrl+R to retry AI • Ctrl+C to quit")
+	return view + "\n" + helpStyle.Render(m.keys.help(m.keys.Select, m.keys.RetryAI, m.keys.Back, m.keys.Quit, m.keys.Help))
 }
 
 func (m Model) viewInputBody() string {
+	help := m.keys.help(m.keys.Done, withDesc(m.keys.Editor, "open in editor"), m.keys.Back, m.keys.Quit)
 	errMsg := ""
 	if m.err != nil {
 		errMsg = errorStyle.Render(fmt.Sprintf("AI error: %v\n\n", m.err))
@@ -615,36 +1583,107 @@ func (m Model) viewInputBody() string {
 		errMsg,
 		titleStyle.Render("Enter commit body (optional)"),
 		m.body.View(),
-		helpStyle.Render("Esc to skip • Tab to confirm • Ctrl+C to quit"),
+		helpStyle.Render(help),
 	)
 }
 
-func (m Model) viewInputFooter() string {
+func (m Model) viewEditTrailers() string {
+	var rows strings.Builder
+	if len(m.trailers) == 0 {
+		rows.WriteString(dimStyle.Render("  (no trailers)") + "\n")
+	}
+	for i, t := range m.trailers {
+		line := t.Token + t.Separator + t.Value
+		if strings.TrimSpace(t.Value) == "" {
+			line += dimStyle.Render("(empty, skipped)")
+		}
+		if i == m.trailerCursor {
+			rows.WriteString(selectedStyle.Render("> "+line) + "\n")
+		} else {
+			rows.WriteString("  " + line + "\n")
+		}
+	}
+	errMsg := ""
+	if m.err != nil {
+		errMsg = errorStyle.Render(fmt.Sprintf("Error: %v\n\n", m.err))
+	}
 	return fmt.Sprintf(
-		"%s\n\n%s\n\n%s",
-		titleStyle.Render("Enter commit footer (optional)"),
+		"%s%s\n\n%s\n%s",
+		errMsg,
+		titleStyle.Render("Edit trailers (footer)"),
+		rows.String(),
+		helpStyle.Render(m.keys.help(
+			m.keys.Up, m.keys.Down, m.keys.AddTrailer, m.keys.CoAuthor, m.keys.EditTrailer, m.keys.DeleteTrailer,
+			withDesc(m.keys.Select, "confirm"), m.keys.Back, m.keys.Quit, m.keys.Help,
+		)),
+	)
+}
+
+func (m Model) viewInputTrailer() string {
+	errMsg := ""
+	if m.err != nil {
+		errMsg = errorStyle.Render(fmt.Sprintf("Error: %v\n\n", m.err))
+	}
+	return fmt.Sprintf(
+		"%s%s\n\n%s\n\n%s",
+		errMsg,
+		titleStyle.Render("Enter trailer"),
 		m.input.View(),
-		helpStyle.Render("Enter to confirm (empty to skip) • Ctrl+C to quit"),
+		helpStyle.Render(m.keys.help(withDesc(m.keys.Select, "save"), withDesc(m.keys.Back, "cancel"), m.keys.Quit)),
+	)
+}
+
+func (m Model) viewInputBreaking() string {
+	errMsg := ""
+	if m.err != nil {
+		errMsg = errorStyle.Render(fmt.Sprintf("AI error: %v\n\n", m.err))
+	}
+	return fmt.Sprintf(
+		"%s%s\n\n%s\n\n%s",
+		errMsg,
+		titleStyle.Render("Describe the breaking change (BREAKING CHANGE footer)"),
+		m.body.View(),
+		helpStyle.Render(m.keys.help(m.keys.Done, withDesc(m.keys.Back, "keep \"!\" only"), m.keys.RetryAI, m.keys.Quit)),
+	)
+}
+
+func (m Model) viewCommitFailed() string {
+	output := m.logOutput
+	if output == "" {
+		output = m.err.Error()
+	}
+	noVerify := m.keys.NoVerify
+	noVerify.SetEnabled(!m.service.CommitOptions().NoVerify)
+	help := m.keys.help(m.keys.Retry, noVerify, withDesc(m.keys.Back, "edit message"), withDesc(m.keys.Abort, "quit"), m.keys.Help)
+	return fmt.Sprintf(
+		"%s\n\n%s\n\n%s",
+		errorStyle.Render("git commit failed"),
+		previewStyle.Render(output),
+		helpStyle.Render(help),
 	)
 }
 
 func (m Model) viewConfirm() string {
-	c := &commit.ConventionalCommit{
-		Type:    m.commitTypeForMessage(),
-		Scope:   m.scope,
-		Subject: m.subject,
-		Body:    m.bodyText,
-		Footer:  m.footer,
-	}
-	preview := m.service.BuildMessage(c)
-	helpText := "y/Enter to commit • n to abort • Esc to edit footer • Ctrl+C to quit"
+	commitKey := m.keys.Commit
+	if m.dryRun {
+		commitKey = withDesc(commitKey, "preview")
+	}
+	helpText := m.keys.help(commitKey, m.keys.Abort, m.keys.Breaking, m.keys.Diff, m.keys.Editor, m.keys.Back, m.keys.Quit, m.keys.Help)
 	if m.dryRun {
-		helpText = "[DRY RUN] y/Enter to preview • n to abort • Esc to edit footer • Ctrl+C to quit"
+		helpText = "[DRY RUN] " + helpText
+	}
+	errMsg := ""
+	if m.err != nil {
+		errMsg = errorStyle.Render(fmt.Sprintf("Error: %v\n\n", m.err))
+	}
+	if m.previewErr != nil {
+		errMsg += errorStyle.Render(fmt.Sprintf("Trailer error (added as plain footers): %v\n\n", m.previewErr))
 	}
 	return fmt.Sprintf(
-		"%s\n\n%s\n\n%s",
+		"%s%s\n\n%s\n\n%s",
+		errMsg,
 		titleStyle.Render("Confirm commit message"),
-		previewStyle.Render(preview),
+		previewStyle.Render(m.preview),
 		helpStyle.Render(helpText),
 	)
 }
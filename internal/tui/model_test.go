@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/hayatosc/git-cx/internal/ai"
 	"github.com/hayatosc/git-cx/internal/app"
+	"github.com/hayatosc/git-cx/internal/commit"
 	"github.com/hayatosc/git-cx/internal/config"
 	"github.com/hayatosc/git-cx/internal/execx"
 	"github.com/hayatosc/git-cx/internal/git"
@@ -79,12 +81,13 @@ func TestView_inputBody(t *testing.T) {
 	}
 }
 
-func TestView_inputFooter(t *testing.T) {
+func TestView_editTrailers(t *testing.T) {
 	m := newModel(false)
-	m.state = stateInputFooter
+	m.state = stateEditTrailers
+	m.trailers = []commit.Footer{{Token: "Refs", Separator: ": ", Value: "#1"}}
 	view := m.View()
-	if !strings.Contains(view, "footer") {
-		t.Errorf("inputFooter view missing 'footer', got: %q", view)
+	if !strings.Contains(view, "trailers") || !strings.Contains(view, "Refs: #1") {
+		t.Errorf("editTrailers view missing trailers, got: %q", view)
 	}
 }
 
@@ -92,7 +95,7 @@ func TestView_confirm(t *testing.T) {
 	m := newModel(false)
 	m.commitType = "feat"
 	m.subject = "add thing"
-	m.state = stateConfirm
+	m = m.enterConfirm()
 	view := m.View()
 	if !strings.Contains(view, "feat: add thing") {
 		t.Errorf("confirm view missing commit message, got: %q", view)
@@ -157,9 +160,20 @@ func TestHandleKey_inputMsg_enter_advancesToSelectDetailMode(t *testing.T) {
 	}
 }
 
-func TestHandleKey_inputFooter_enter_advancesToConfirm(t *testing.T) {
+func TestHandleKey_inputBody_tabWithEmptyBodySkips(t *testing.T) {
+	m := newModel(false)
+	m.state = stateInputBody
+	m.bodyText = "stale"
+	result, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyTab})
+	next := result.(Model)
+	if next.state != stateEditTrailers || next.bodyText != "" {
+		t.Errorf("expected stateEditTrailers with no body, got %v %q", next.state, next.bodyText)
+	}
+}
+
+func TestHandleKey_editTrailers_enter_advancesToConfirm(t *testing.T) {
 	m := newModel(false)
-	m.state = stateInputFooter
+	m.state = stateEditTrailers
 	result, _ := m.handleKey(pressEnter())
 	next := result.(Model)
 	if next.state != stateConfirm {
@@ -167,6 +181,52 @@ func TestHandleKey_inputFooter_enter_advancesToConfirm(t *testing.T) {
 	}
 }
 
+func TestHandleKey_editTrailers_addEditDelete(t *testing.T) {
+	m := newModel(false)
+	m.state = stateEditTrailers
+	typeLine := func(m Model, line string) Model {
+		m.input.SetValue(line)
+		result, _ := m.handleKey(pressEnter())
+		return result.(Model)
+	}
+
+	result, _ := m.handleKey(pressKey('a'))
+	m = typeLine(result.(Model), "Refs: #1")
+	result, _ = m.handleKey(pressKey('a'))
+	m = typeLine(result.(Model), "Reviewed-by: Bob <bob@example.com>")
+	if m.state != stateEditTrailers || len(m.trailers) != 2 || m.trailerCursor != 1 {
+		t.Fatalf("unexpected rows: %#v cursor %d", m.trailers, m.trailerCursor)
+	}
+
+	result, _ = m.handleKey(pressKey('a'))
+	m = typeLine(result.(Model), "not a trailer")
+	if m.state != stateInputTrailer || m.err == nil {
+		t.Fatalf("expected validation error, got state %v err %v", m.state, m.err)
+	}
+	result, _ = m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	m = result.(Model)
+
+	result, _ = m.handleKey(pressKey('k'))
+	m = result.(Model)
+	result, _ = m.handleKey(pressKey('e'))
+	m = typeLine(result.(Model), "Refs #2")
+	if m.trailers[0] != (commit.Footer{Token: "Refs", Separator: " #", Value: "2"}) {
+		t.Fatalf("unexpected edited row: %#v", m.trailers[0])
+	}
+
+	result, _ = m.handleKey(pressKey('d'))
+	m = result.(Model)
+	if len(m.trailers) != 1 || m.trailers[0].Token != "Reviewed-by" {
+		t.Fatalf("unexpected rows after delete: %#v", m.trailers)
+	}
+
+	result, _ = m.handleKey(pressKey('a'))
+	m = typeLine(result.(Model), "BREAKING CHANGE: removed flag")
+	if !m.breaking || m.breakingNote != "removed flag" || len(m.trailers) != 1 {
+		t.Fatalf("expected BREAKING CHANGE to set the breaking note, got %v %q %#v", m.breaking, m.breakingNote, m.trailers)
+	}
+}
+
 func TestHandleKey_confirm_y_advancesToDone(t *testing.T) {
 	m := newModel(false)
 	m.commitType = "feat"
@@ -215,7 +275,7 @@ func TestHandleKey_ctrlC_quits(t *testing.T) {
 func TestDoCommit_propagatesError(t *testing.T) {
 	mock := &execx.MockRunner{
 		Errors: map[string]error{
-			"git\x00commit\x00-m\x00feat: fail": errors.New("git error"),
+			"git\x00commit\x00-F\x00-": errors.New("git error"),
 		},
 	}
 	service := newTestService(mock)
@@ -235,15 +295,54 @@ func TestDoCommit_propagatesError(t *testing.T) {
 	}
 }
 
-func TestUpdate_commitDoneMsg_setsError(t *testing.T) {
+func TestUpdate_commitDoneMsg_showsFailure(t *testing.T) {
 	m := newModel(false)
-	result, _ := m.Update(commitDoneMsg{err: errors.New("commit failed")})
+	result, _ := m.Update(commitDoneMsg{err: errors.New("commit failed"), output: "trailing whitespace found\nin main.go"})
 	next := result.(Model)
 	if next.err == nil || !strings.Contains(next.err.Error(), "commit failed") {
 		t.Errorf("expected err to be set, got %v", next.err)
 	}
-	if !next.quitting {
-		t.Error("expected quitting=true")
+	if next.quitting || next.state != stateCommitFailed {
+		t.Fatalf("expected to stay in stateCommitFailed, got state=%d quitting=%v", next.state, next.quitting)
+	}
+	if view := next.View(); !strings.Contains(view, "trailing whitespace found") || !strings.Contains(view, "in main.go") {
+		t.Errorf("failure view missing hook output, got: %q", view)
+	}
+}
+
+func TestCommitFailed_retryWithNoVerify(t *testing.T) {
+	mock := &execx.MockRunner{}
+	m := New(newTestService(mock), "diff", "stat", false)
+	m.commitType = "feat"
+	m.subject = "retry"
+	m.state = stateCommitFailed
+	m.err = errors.New("hook failed")
+
+	result, cmd := m.handleKey(pressKey('v'))
+	next := result.(Model)
+	if next.state != stateDone || next.err != nil || cmd == nil {
+		t.Fatalf("expected retry, got state=%d err=%v", next.state, next.err)
+	}
+	if done := cmd().(commitDoneMsg); done.err != nil {
+		t.Fatalf("unexpected error: %v", done.err)
+	}
+	call := mock.Calls[len(mock.Calls)-1]
+	if strings.Join(call.Args, " ") != "commit -F - --no-verify" || call.Input != "feat: retry" {
+		t.Fatalf("unexpected commit call: %q input %q", call.Args, call.Input)
+	}
+}
+
+func TestCommitFailed_escReturnsToConfirm(t *testing.T) {
+	m := newModel(false)
+	m.commitType = "feat"
+	m.subject = "retry"
+	m.state = stateCommitFailed
+	m.err = errors.New("hook failed")
+
+	result, _ := m.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	next := result.(Model)
+	if next.state != stateConfirm || next.err != nil {
+		t.Fatalf("expected stateConfirm, got state=%d err=%v", next.state, next.err)
 	}
 }
 
@@ -279,8 +378,54 @@ func TestHandleAIResult_populatesMsgList(t *testing.T) {
 	if next.state != stateSelectMsg {
 		t.Errorf("expected stateSelectMsg, got %v", next.state)
 	}
-	if len(next.msgList.Items()) != 4 { // 2 candidates + Manual + Regenerate
-		t.Errorf("expected 4 items in msgList, got %d", len(next.msgList.Items()))
+	if len(next.msgList.Items()) != 5 { // 2 candidates + Manual + Regenerate + Regenerate with hint
+		t.Errorf("expected 5 items in msgList, got %d", len(next.msgList.Items()))
+	}
+}
+
+func TestHandleAIResult_rationaleAsDescription(t *testing.T) {
+	m := newModel(false)
+	m.state = stateAILoading
+	result, _ := m.handleAIResult(aiResultMsg{
+		candidates: []string{"fix(api): handle nil body", "feat: a"},
+		rationales: []string{"fix because a nil check was added in the handler", ""},
+	})
+	items := result.(Model).msgList.Items()
+	if got := items[0].(item).desc; got != "fix because a nil check was added in the handler" {
+		t.Errorf("expected the rationale as description, got %q", got)
+	}
+	if got := items[1].(item).desc; got != "" {
+		t.Errorf("expected no description without a rationale, got %q", got)
+	}
+}
+
+func TestRegenerateWithHint(t *testing.T) {
+	provider := &ai.MockProvider{Candidates: []string{"feat: shorter"}}
+	service := app.NewCommitService(&config.Config{Candidates: 1}, provider, git.NewRunnerWithExecutor(&execx.MockRunner{}))
+	m := New(service, "diff", "stat", false)
+	m.commitType = commit.AutoType
+	result, _ := m.handleAIResult(aiResultMsg{candidates: []string{"feat: a long one"}})
+	m = result.(Model)
+
+	m.msgList.Select(3) // [Regenerate with hint…]
+	m, _ = update(t, m, pressEnter())
+	if m.state != stateInputHint {
+		t.Fatalf("expected stateInputHint, got %v", m.state)
+	}
+	m = typeText(t, m, "shorter")
+	m, cmd := update(t, m, pressEnter())
+	if m.state != stateAILoading || cmd == nil {
+		t.Fatalf("expected a new AI call, got state %v", m.state)
+	}
+	batch := cmd().(tea.BatchMsg)
+	m, _ = update(t, m, batch[1]())
+
+	req := provider.LastReq
+	if req == nil || req.Hint != "shorter" || len(req.Rejected) != 1 || req.Rejected[0] != "feat: a long one" {
+		t.Fatalf("hint and rejected candidates not sent: %#v", req)
+	}
+	if m.state != stateSelectMsg || m.candidates[0] != "feat: shorter" {
+		t.Fatalf("expected new candidates, got state %v candidates %v", m.state, m.candidates)
 	}
 }
 
@@ -297,6 +442,261 @@ func TestHandleAIResult_errorFallsBackToInputMsg(t *testing.T) {
 	}
 }
 
+// --- candidate editing ---
+
+func TestSelectMsg_editCandidate(t *testing.T) {
+	m := newModel(false)
+	m.commitType = commit.AutoType
+	result, _ := m.handleAIResult(aiResultMsg{candidates: []string{"fix(api)!: handle nil body"}})
+	m = result.(Model)
+
+	result, _ = m.Update(pressKey('e'))
+	m = result.(Model)
+	if m.state != stateEditMsg {
+		t.Fatalf("expected stateEditMsg, got %v", m.state)
+	}
+	got := []string{m.editFields[editType].Value(), m.editFields[editScope].Value(), m.editFields[editSubject].Value()}
+	if strings.Join(got, "|") != "fix!|api|handle nil body" {
+		t.Fatalf("unexpected fields: %q", got)
+	}
+
+	for _, r := range " in handler" {
+		result, _ = m.Update(pressKey(r))
+		m = result.(Model)
+	}
+	result, _ = m.Update(pressEnter())
+	m = result.(Model)
+	if m.state != stateSelectDetailMode {
+		t.Fatalf("expected stateSelectDetailMode, got %v", m.state)
+	}
+	if m.commitType != "fix" || m.scope != "api" || !m.breaking || m.subject != "handle nil body in handler" {
+		t.Fatalf("unexpected result: type %q scope %q breaking %v subject %q", m.commitType, m.scope, m.breaking, m.subject)
+	}
+	if msg := m.service.BuildMessage(m.conventionalCommit()); msg != "fix(api)!: handle nil body in handler" {
+		t.Fatalf("unexpected message: %q", msg)
+	}
+}
+
+func TestSelectMsg_editKeepsChosenType(t *testing.T) {
+	m := newModel(false)
+	m.commitType = "feat"
+	m.scope = "core"
+	result, _ := m.handleAIResult(aiResultMsg{candidates: []string{"add retry"}})
+	m = result.(Model)
+
+	result, _ = m.Update(pressKey('e'))
+	m = result.(Model)
+	got := []string{m.editFields[editType].Value(), m.editFields[editScope].Value(), m.editFields[editSubject].Value()}
+	if strings.Join(got, "|") != "feat|core|add retry" {
+		t.Fatalf("unexpected fields: %q", got)
+	}
+}
+
+func TestEditMsg_validationBlocksEnter(t *testing.T) {
+	m := newModel(false)
+	m.service = app.NewCommitService(
+		&config.Config{Candidates: 1, Commit: config.CommitConfig{MaxSubjectLength: 10}},
+		&ai.MockProvider{},
+		git.NewRunnerWithExecutor(&execx.MockRunner{}),
+	)
+	m.commitType = commit.AutoType
+	result, _ := m.handleAIResult(aiResultMsg{candidates: []string{"feat: add a rather long subject"}})
+	m = result.(Model)
+	result, _ = m.Update(pressKey('e'))
+	m = result.(Model)
+
+	if view := m.View(); !strings.Contains(view, "max is 10") {
+		t.Errorf("expected subject length error in view, got: %q", view)
+	}
+	result, _ = m.Update(pressEnter())
+	m = result.(Model)
+	if m.state != stateEditMsg {
+		t.Fatalf("expected to stay in stateEditMsg, got %v", m.state)
+	}
+
+	m.editFields[editSubject].SetValue("add it")
+	m.editFields[editType].SetValue("feature")
+	if view := m.View(); !strings.Contains(view, "type-enum") {
+		t.Errorf("expected type-enum error in view, got: %q", view)
+	}
+	m.editFields[editType].SetValue("feat")
+	result, _ = m.Update(pressEnter())
+	if next := result.(Model); next.state != stateSelectDetailMode {
+		t.Fatalf("expected valid header to be accepted, got %v", next.state)
+	}
+}
+
+func TestEditMsg_escReturnsToList(t *testing.T) {
+	m := newModel(false)
+	result, _ := m.handleAIResult(aiResultMsg{candidates: []string{"feat: a"}})
+	m = result.(Model)
+	result, _ = m.Update(pressKey('e'))
+	m = result.(Model)
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if next := result.(Model); next.state != stateSelectMsg {
+		t.Fatalf("expected stateSelectMsg, got %v", next.state)
+	}
+}
+
+// --- external editor ---
+
+func TestDraftMessage_includesTypedBody(t *testing.T) {
+	m := newModel(false)
+	m.commitType = "feat"
+	m.scope = "tui"
+	m.subject = "open editor"
+	m.trailers = []commit.Footer{{Token: "Refs", Separator: ": ", Value: "#12"}, {Token: "Signed-off-by", Separator: ": "}}
+	m.state = stateInputBody
+	m.body.SetValue("Typed so far.")
+
+	if got := m.draftMessage(); got != "feat(tui): open editor\n\nTyped so far.\n\nRefs: #12" {
+		t.Fatalf("unexpected draft: %q", got)
+	}
+}
+
+func TestEditorDone_parsesMessage(t *testing.T) {
+	m := newModel(false)
+	m.commitType = "feat"
+	m.subject = "old"
+	m.state = stateInputBody
+
+	result, _ := m.Update(editorDoneMsg{message: "fix(api): handle nil\n\nLonger body.\n\nRefs: #3\nBREAKING CHANGE: nil is rejected"})
+	m = result.(Model)
+	if m.state != stateConfirm {
+		t.Fatalf("expected stateConfirm, got %v", m.state)
+	}
+	if m.commitType != "fix" || m.scope != "api" || m.subject != "handle nil" || m.bodyText != "Longer body." {
+		t.Fatalf("unexpected parts: %q %q %q %q", m.commitType, m.scope, m.subject, m.bodyText)
+	}
+	if !m.breaking || m.breakingNote != "nil is rejected" || len(m.trailers) != 1 || m.trailers[0].Value != "#3" {
+		t.Fatalf("unexpected footers: breaking %v note %q trailers %+v", m.breaking, m.breakingNote, m.trailers)
+	}
+}
+
+func TestEditorDone_keepsFreeFormMessage(t *testing.T) {
+	m := newModel(false)
+	m.commitType = "feat"
+
+	result, _ := m.Update(editorDoneMsg{message: "Update vendored deps\n\nRan go mod vendor."})
+	m = result.(Model)
+	if got := m.service.BuildMessage(m.conventionalCommit()); got != "Update vendored deps\n\nRan go mod vendor." {
+		t.Fatalf("unexpected message: %q", got)
+	}
+}
+
+func TestEditorDone_emptyMessageKeepsState(t *testing.T) {
+	m := newModel(false)
+	m.state = stateInputBody
+	result, _ := m.Update(editorDoneMsg{})
+	m = result.(Model)
+	if m.state != stateInputBody || m.err == nil {
+		t.Fatalf("expected to stay with an error, got state %v err %v", m.state, m.err)
+	}
+}
+
+// --- breaking changes ---
+
+func TestSelectMsg_candidateWithBangSetsBreaking(t *testing.T) {
+	m := newModel(false)
+	result, _ := m.handleAIResult(aiResultMsg{candidates: []string{"feat(api)!: drop v1"}})
+	m = result.(Model)
+	result, _ = m.Update(pressEnter())
+	next := result.(Model)
+	if !next.breaking {
+		t.Fatal("expected breaking to follow the candidate's \"!\"")
+	}
+}
+
+func TestConfirm_toggleBreaking(t *testing.T) {
+	m := newModel(false)
+	m.service = app.NewCommitService(
+		&config.Config{Candidates: 1},
+		&ai.MockProvider{Output: "Load now takes a context."},
+		git.NewRunnerWithExecutor(&execx.MockRunner{}),
+	)
+	m.subject = "feat(config): load lazily"
+	m.state = stateConfirm
+
+	result, cmd := m.Update(pressKey('b'))
+	m = result.(Model)
+	if m.state != stateBreakingAILoading || !m.breaking || cmd == nil {
+		t.Fatalf("expected AI pre-fill, got state %v breaking %v", m.state, m.breaking)
+	}
+	result, _ = m.Update(m.generateAIBreaking(context.Background())())
+	m = result.(Model)
+	if m.state != stateInputBreaking || m.body.Value() != "Load now takes a context." {
+		t.Fatalf("unexpected state %v value %q", m.state, m.body.Value())
+	}
+	result, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = result.(Model)
+	got := m.service.BuildMessage(m.conventionalCommit())
+	want := "feat(config)!: load lazily\n\nBREAKING CHANGE: Load now takes a context."
+	if m.state != stateConfirm || got != want {
+		t.Fatalf("unexpected message in state %v:\n%s", m.state, got)
+	}
+
+	result, _ = m.Update(pressKey('b'))
+	m = result.(Model)
+	if got := m.service.BuildMessage(m.conventionalCommit()); got != "feat(config): load lazily" {
+		t.Fatalf("expected breaking change removed, got:\n%s", got)
+	}
+}
+
+func TestHandleAIDetailResult_movesBreakingFooter(t *testing.T) {
+	m := newModel(false)
+	result, _ := m.handleAIDetailResult(aiDetailResultMsg{body: "b", footer: "Refs: #1\nBREAKING CHANGE: removed flag"})
+	next := result.(Model)
+	if !next.breaking || next.breakingNote != "removed flag" || len(next.trailers) != 1 || next.trailers[0].Token != "Refs" {
+		t.Fatalf("unexpected breaking state: %v %q %#v", next.breaking, next.breakingNote, next.trailers)
+	}
+}
+
+func TestHandleAIDetailResult_keepsUserTrailers(t *testing.T) {
+	m := newModel(false)
+	result, _ := m.handleAIDetailResult(aiDetailResultMsg{body: "b", footer: "Refs: #1"})
+	m = result.(Model)
+	m.trailers = append(m.trailers, coAuthorTrailer("Jane Doe <jane@example.com>"))
+
+	result, _ = m.handleAIDetailResult(aiDetailResultMsg{body: "b", footer: "Refs: #2"})
+	m = result.(Model)
+	if len(m.trailers) != 2 || m.trailers[0].Token != "Co-authored-by" || m.trailers[1].Value != "#2" {
+		t.Fatalf("expected the co-author kept and the AI trailer replaced, got %#v", m.trailers)
+	}
+}
+
+// --- merge/revert/cherry-pick ---
+
+func TestWithOperation_revertPrefillsBody(t *testing.T) {
+	mock := &execx.MockRunner{
+		Results: map[string]execx.Result{
+			"git\x00log\x00-1\x00--format=%H%x00%B\x00abc123\x00--": {Stdout: "abc123\x00feat: add feature\n"},
+		},
+	}
+	m := New(newTestService(mock), "diff", "stat", false).WithOperation(git.Operation{Kind: git.OperationRevert, Head: "abc123"})
+	if m.state != stateAILoading {
+		t.Fatalf("expected stateAILoading, got %v", m.state)
+	}
+	msg := m.generateOperation(context.Background())()
+	result, _ := m.Update(msg)
+	next := result.(Model)
+	if next.state != stateInputBody || next.subject != "revert: feat: add feature" {
+		t.Fatalf("unexpected state %v subject %q", next.state, next.subject)
+	}
+	if next.body.Value() != "This reverts commit abc123." {
+		t.Fatalf("unexpected body: %q", next.body.Value())
+	}
+}
+
+func TestHandleOperationResult_noSubjectFallsBackToInputMsg(t *testing.T) {
+	m := newModel(false)
+	m.state = stateAILoading
+	result, _ := m.handleOperationResult(operationResultMsg{err: errors.New("git log failed")})
+	next := result.(Model)
+	if next.state != stateInputMsg || next.err == nil {
+		t.Fatalf("expected stateInputMsg with error, got %v %v", next.state, next.err)
+	}
+}
+
 // --- dry-run mode ---
 
 func TestDryRun_doCommit_skipsCommit(t *testing.T) {
@@ -363,3 +763,69 @@ func TestDryRun_View_confirmState(t *testing.T) {
 		t.Errorf("expected '[DRY RUN]' in confirm help text, got: %q", view)
 	}
 }
+
+func TestHandleKey_editTrailers_coAuthor(t *testing.T) {
+	m := newModel(false)
+	m.state = stateEditTrailers
+	_, cmd := m.handleKey(pressKey('c'))
+	if cmd == nil {
+		t.Fatal("expected a command loading co-authors")
+	}
+
+	result, _ := m.Update(coAuthorsMsg{coAuthors: []app.CoAuthor{
+		{Ident: "Alice <alice@example.com>", Configured: true},
+		{Ident: "Bob <bob@example.com>"},
+	}})
+	m = result.(Model)
+	if m.state != stateSelectCoAuthor {
+		t.Fatalf("expected stateSelectCoAuthor, got %v", m.state)
+	}
+	result, _ = m.handleKey(pressEnter())
+	m = result.(Model)
+	want := commit.Footer{Token: "Co-authored-by", Separator: ": ", Value: "Alice <alice@example.com>"}
+	if m.state != stateEditTrailers || len(m.trailers) != 1 || m.trailers[0] != want {
+		t.Fatalf("unexpected rows: %#v (state %v)", m.trailers, m.state)
+	}
+
+	result, _ = m.Update(coAuthorsMsg{coAuthors: []app.CoAuthor{{Ident: "Alice <alice@example.com>", Configured: true}}})
+	result, _ = result.(Model).handleKey(pressEnter())
+	m = result.(Model)
+	if len(m.trailers) != 1 {
+		t.Fatalf("expected no duplicate co-author, got %#v", m.trailers)
+	}
+
+	result, _ = m.Update(coAuthorsMsg{})
+	m = result.(Model)
+	if m.state != stateEditTrailers || m.err == nil {
+		t.Fatalf("expected an error for no suggestions, got state %v err %v", m.state, m.err)
+	}
+}
+
+func TestSelectMsg_gitmojiPicker(t *testing.T) {
+	m := New(app.NewCommitService(
+		&config.Config{Candidates: 1, Commit: config.CommitConfig{Gitmoji: true, EmojiPlacement: commit.EmojiPlacementPrefix}},
+		&ai.MockProvider{},
+		git.NewRunnerWithExecutor(&execx.MockRunner{}),
+	), "diff", "stat", false)
+	m.commitType = commit.AutoType
+	result, _ := m.handleAIResult(aiResultMsg{candidates: []string{"fix(auth): :lock: rotate keys"}})
+	m = result.(Model)
+	result, _ = m.Update(pressEnter())
+	m = result.(Model)
+	if m.state != stateSelectGitmoji || m.subject != "fix(auth): rotate keys" {
+		t.Fatalf("unexpected state %v subject %q", m.state, m.subject)
+	}
+	if i, ok := m.gitmojiList.SelectedItem().(gitmojiItem); !ok || i.Code != "lock" {
+		t.Fatalf("expected the AI's gitmoji preselected, got %#v", m.gitmojiList.SelectedItem())
+	}
+
+	m.gitmojiList.Select(3) // :bug:
+	result, _ = m.Update(pressEnter())
+	m = result.(Model)
+	if m.state != stateSelectDetailMode || m.emoji != "🐛" {
+		t.Fatalf("unexpected state %v emoji %q", m.state, m.emoji)
+	}
+	if got := m.service.BuildMessage(m.conventionalCommit()); got != "🐛 fix(auth): rotate keys" {
+		t.Fatalf("unexpected message: %q", got)
+	}
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// navStep returns the step of the back stack that s belongs to. Screens
+// opened from a step (editing a candidate or a trailer, a regenerate hint,
+// the breaking-change note, a failed commit) count as that step; loading
+// screens are no step.
+func navStep(s State) (State, bool) {
+	switch s {
+	case stateSelectType, stateInputScope, stateSelectMsg, stateInputMsg, stateSelectGitmoji,
+		stateSelectDetailMode, stateInputBody, stateEditTrailers, stateConfirm:
+		return s, true
+	case stateEditMsg, stateInputHint:
+		return stateSelectMsg, true
+	case stateInputTrailer, stateSelectCoAuthor:
+		return stateEditTrailers, true
+	case stateInputBreaking, stateBreakingAILoading, stateCommitFailed, stateDone:
+		return stateConfirm, true
+	}
+	return s, false
+}
+
+// record pushes the step the model was on before an update onto the back
+// stack when the update moved it to another step. Updates that went back
+// (and so shortened the stack) are not recorded.
+func (m Model) record(prev State, depth int) Model {
+	if len(m.history) != depth {
+		return m
+	}
+	from, ok := navStep(prev)
+	if to, _ := navStep(m.state); ok && from != to {
+		m.history = append(m.history, from)
+	}
+	return m
+}
+
+// canGoBack reports whether Esc goes back a step rather than doing what the
+// screen itself uses it for.
+func (m Model) canGoBack() bool {
+	if len(m.history) == 0 {
+		return false
+	}
+	if step, _ := navStep(m.state); step != m.state {
+		return false
+	}
+	if m.state == stateSelectGitmoji && m.gitmojiList.FilterState() != list.Unfiltered {
+		return false
+	}
+	return true
+}
+
+// back returns to the previous step with its values as they were left; AI
+// results are kept, not generated again.
+func (m Model) back() (tea.Model, tea.Cmd) {
+	prev := m.state
+	for prev == m.state && len(m.history) > 0 {
+		prev = m.history[len(m.history)-1]
+		m.history = m.history[:len(m.history)-1]
+	}
+	if prev == m.state {
+		return m, nil
+	}
+	if m.state == stateInputBody {
+		m.bodyText = m.body.Value()
+	}
+	m.err = nil
+	m.state = prev
+	switch prev {
+	case stateInputScope:
+		m.input.Placeholder = scopePlaceholder
+		m.input.SetValue(m.scope)
+		return m, m.input.Focus()
+	case stateInputMsg:
+		m.input.Placeholder = m.subjectPlaceholder()
+		m.input.SetValue(m.subject)
+		return m, m.input.Focus()
+	case stateInputBody:
+		m.body.SetValue(m.bodyText)
+		return m, m.body.Focus()
+	case stateConfirm:
+		return m.enterConfirm(), nil
+	}
+	return m, nil
+}
+
+// candidatesKey identifies the request the candidates in msgList answer.
+func (m Model) candidatesKey() string {
+	return m.commitType + "\x00" + m.scope
+}
+
+// detailKey identifies the request an AI body and footer answer.
+func (m Model) detailKey() string {
+	return m.commitType + "\x00" + m.scope + "\x00" + m.subject
+}
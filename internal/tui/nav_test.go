@@ -0,0 +1,218 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/hayatosc/git-cx/internal/git"
+)
+
+func pressEsc() tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyEsc} }
+
+func update(t *testing.T, m Model, msg tea.Msg) (Model, tea.Cmd) {
+	t.Helper()
+	result, cmd := m.Update(msg)
+	return result.(Model), cmd
+}
+
+func typeText(t *testing.T, m Model, text string) Model {
+	t.Helper()
+	for _, r := range text {
+		m, _ = update(t, m, pressKey(r))
+	}
+	return m
+}
+
+// toDetailMode walks from the type list to the detail mode list with scope
+// "core" and one AI candidate.
+func toDetailMode(t *testing.T) Model {
+	t.Helper()
+	m, _ := update(t, newModel(false), tea.WindowSizeMsg{Width: 80, Height: 30})
+	m, _ = update(t, m, pressEnter()) // auto
+	m = typeText(t, m, "core")
+	m, _ = update(t, m, pressEnter())
+	m, _ = update(t, m, aiResultMsg{candidates: []string{"feat(core): add x"}})
+	m, _ = update(t, m, pressEnter())
+	if m.state != stateSelectDetailMode {
+		t.Fatalf("expected stateSelectDetailMode, got %v", m.state)
+	}
+	return m
+}
+
+func TestBack_walksToTypeKeepingCandidates(t *testing.T) {
+	m := toDetailMode(t)
+
+	m, _ = update(t, m, pressEsc())
+	if m.state != stateSelectMsg {
+		t.Fatalf("expected stateSelectMsg, got %v", m.state)
+	}
+	m, _ = update(t, m, pressEsc())
+	if m.state != stateInputScope || m.input.Value() != "core" {
+		t.Fatalf("expected scope input with %q, got state %v value %q", "core", m.state, m.input.Value())
+	}
+
+	m, cmd := update(t, m, pressEnter())
+	if m.state != stateSelectMsg || cmd != nil {
+		t.Fatalf("expected cached candidates without a new AI call, got state %v", m.state)
+	}
+	if len(m.msgList.Items()) != 4 {
+		t.Fatalf("expected candidate list to be kept, got %d items", len(m.msgList.Items()))
+	}
+
+	m, _ = update(t, m, pressEsc())
+	m, _ = update(t, m, pressEsc())
+	if m.state != stateSelectType || len(m.history) != 0 {
+		t.Fatalf("expected type list with empty history, got state %v history %v", m.state, m.history)
+	}
+}
+
+func TestBack_changedScopeRegenerates(t *testing.T) {
+	m := toDetailMode(t)
+	m, _ = update(t, m, pressEsc())
+	m, _ = update(t, m, pressEsc())
+	m = typeText(t, m, "s")
+
+	m, cmd := update(t, m, pressEnter())
+	if m.state != stateAILoading || cmd == nil {
+		t.Fatalf("expected a new AI call for scope %q, got state %v", m.scope, m.state)
+	}
+}
+
+func TestBack_keepsTypedBody(t *testing.T) {
+	m := toDetailMode(t)
+	m.detailList.Select(2) // [Manual entry]
+	m, _ = update(t, m, pressEnter())
+	m = typeText(t, m, "hello")
+
+	m, _ = update(t, m, pressEsc())
+	if m.state != stateSelectDetailMode || m.bodyText != "hello" {
+		t.Fatalf("expected detail mode with body kept, got state %v body %q", m.state, m.bodyText)
+	}
+	m, _ = update(t, m, pressEnter())
+	if m.state != stateInputBody || m.body.Value() != "hello" {
+		t.Fatalf("expected body input with %q, got state %v value %q", "hello", m.state, m.body.Value())
+	}
+}
+
+func TestBack_reusesAIDetails(t *testing.T) {
+	m := toDetailMode(t)
+	m.detailList.Select(1) // [Generate with AI]
+	m, _ = update(t, m, pressEnter())
+	if m.state != stateDetailAILoading {
+		t.Fatalf("expected stateDetailAILoading, got %v", m.state)
+	}
+	m, _ = update(t, m, aiDetailResultMsg{body: "AI body"})
+	m, _ = update(t, m, pressEsc())
+	if m.state != stateSelectDetailMode {
+		t.Fatalf("expected stateSelectDetailMode, got %v", m.state)
+	}
+
+	m.detailList.Select(1)
+	m, cmd := update(t, m, pressEnter())
+	if m.state != stateInputBody || cmd != nil || m.body.Value() != "AI body" {
+		t.Fatalf("expected cached AI body, got state %v value %q", m.state, m.body.Value())
+	}
+}
+
+func TestBack_fromConfirmAfterSkip(t *testing.T) {
+	m := toDetailMode(t)
+	m.detailList.Select(0) // [Skip]
+	m, _ = update(t, m, pressEnter())
+	if m.state != stateConfirm {
+		t.Fatalf("expected stateConfirm, got %v", m.state)
+	}
+	m, _ = update(t, m, pressEsc())
+	if m.state != stateSelectDetailMode {
+		t.Fatalf("expected to go back to detail mode, got %v", m.state)
+	}
+}
+
+func TestBack_subScreensKeepTheirEsc(t *testing.T) {
+	m := toDetailMode(t)
+	m, _ = update(t, m, pressEsc())
+	m, _ = update(t, m, pressKey('e'))
+	if m.state != stateEditMsg {
+		t.Fatalf("expected stateEditMsg, got %v", m.state)
+	}
+	m, _ = update(t, m, pressEsc())
+	if m.state != stateSelectMsg {
+		t.Fatalf("expected Esc to close the editor, got %v", m.state)
+	}
+	m, _ = update(t, m, pressEsc())
+	if m.state != stateInputScope {
+		t.Fatalf("expected scope input, got %v", m.state)
+	}
+}
+
+func TestCancelAI_returnsToScope(t *testing.T) {
+	m, _ := update(t, newModel(false), pressEnter()) // auto
+	m = typeText(t, m, "core")
+	m, _ = update(t, m, pressEnter())
+	if m.state != stateAILoading {
+		t.Fatalf("expected stateAILoading, got %v", m.state)
+	}
+	if view := m.View(); !strings.Contains(view, "(0s)") || !strings.Contains(view, "Esc to cancel") {
+		t.Fatalf("expected elapsed time and cancel help, got:\n%s", view)
+	}
+
+	m, _ = update(t, m, pressEsc())
+	if m.state != stateInputScope || m.input.Value() != "core" {
+		t.Fatalf("expected scope input with %q, got state %v value %q", "core", m.state, m.input.Value())
+	}
+}
+
+func TestCancelAI_dropsResult(t *testing.T) {
+	m := newModel(false).WithOperation(git.Operation{Kind: git.OperationMerge})
+	m, cmd := m.startAI(stateAILoading, func(ctx context.Context) tea.Cmd {
+		return func() tea.Msg {
+			<-ctx.Done()
+			return aiResultMsg{err: ctx.Err()}
+		}
+	})
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("expected spinner tick and request, got %#v", cmd())
+	}
+	result := make(chan tea.Msg)
+	go func() { result <- batch[1]() }()
+
+	m, _ = update(t, m, pressEsc())
+	if m.state != stateInputMsg {
+		t.Fatalf("expected manual entry with nothing to go back to, got %v", m.state)
+	}
+	if msg := <-result; msg != nil {
+		t.Fatalf("expected the cancelled result to be dropped, got %#v", msg)
+	}
+}
+
+func TestCancelAI_breakingNoteTypedByHand(t *testing.T) {
+	m := toDetailMode(t)
+	m.detailList.Select(0) // [Skip]
+	m, _ = update(t, m, pressEnter())
+	m, _ = update(t, m, pressKey('b'))
+	if m.state != stateBreakingAILoading {
+		t.Fatalf("expected stateBreakingAILoading, got %v", m.state)
+	}
+	m, _ = update(t, m, pressEsc())
+	if m.state != stateInputBreaking || !m.breaking {
+		t.Fatalf("expected breaking note input, got state %v breaking %v", m.state, m.breaking)
+	}
+}
+
+func TestCancelAI_ctrlCWaitsForRequest(t *testing.T) {
+	m := newModel(false)
+	m.state = stateAILoading
+	canceled := false
+	m.cancelAI = func() { canceled = true }
+	done := make(chan struct{})
+	close(done)
+	m.aiDone = done
+
+	m, cmd := update(t, m, tea.KeyMsg{Type: tea.KeyCtrlC})
+	if !canceled || !m.quitting || cmd == nil {
+		t.Fatalf("expected the request to be cancelled before quitting, canceled %v quitting %v", canceled, m.quitting)
+	}
+}
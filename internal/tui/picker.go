@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Picker is a single-step model for choosing one of several AI candidates.
+// It is shared by the commands that generate text outside the commit flow.
+type Picker struct {
+	list     list.Model
+	choice   string
+	quitting bool
+}
+
+// NewPicker creates a Picker listing options under title.
+func NewPicker(title string, options []string) Picker {
+	items := make([]list.Item, len(options))
+	for i, o := range options {
+		items[i] = item{title: o}
+	}
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l.Title = title
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	return Picker{list: l}
+}
+
+// Choice returns the selected option; ok is false when the user aborted.
+func (p Picker) Choice() (string, bool) {
+	return p.choice, p.choice != ""
+}
+
+func (p Picker) Init() tea.Cmd {
+	return nil
+}
+
+func (p Picker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.list.SetSize(msg.Width, msg.Height-2)
+		return p, nil
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			p.quitting = true
+			return p, tea.Quit
+		case tea.KeyEnter:
+			if i, ok := p.list.SelectedItem().(item); ok {
+				p.choice = i.title
+			}
+			p.quitting = true
+			return p, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	p.list, cmd = p.list.Update(msg)
+	return p, cmd
+}
+
+// View renders the list, or nothing once a choice was made.
+func (p Picker) View() string {
+	if p.quitting {
+		return ""
+	}
+	return p.list.View() + "\n" + helpStyle.Render("Enter to select • Esc to abort • Ctrl+C to quit")
+}
@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPicker_EnterSelects(t *testing.T) {
+	p := NewPicker("Select", []string{"feat: a", "feat: b"})
+	result, _ := p.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	result, _ = result.(Picker).Update(tea.KeyMsg{Type: tea.KeyDown})
+	result, cmd := result.(Picker).Update(pressEnter())
+	choice, ok := result.(Picker).Choice()
+	if !ok || choice != "feat: b" {
+		t.Fatalf("unexpected choice: %q %v", choice, ok)
+	}
+	if cmd == nil {
+		t.Fatal("expected quit command")
+	}
+}
+
+func TestPicker_EscAborts(t *testing.T) {
+	p := NewPicker("Select", []string{"feat: a"})
+	result, _ := p.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := result.(Picker).Choice(); ok {
+		t.Fatal("expected no choice after Esc")
+	}
+}
@@ -1,31 +1,167 @@
 package tui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"maps"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// themes holds the colours of each cx.ui.theme by style name, as listed in
+// config.UIStyles. A style without a colour uses the terminal's default.
+var themes = map[string]map[string]string{
+	"dark": {
+		"title":       "205",
+		"subtitle":    "241",
+		"selected":    "212",
+		"dim":         "238",
+		"error":       "196",
+		"border":      "63",
+		"help":        "241",
+		"diff-add":    "34",
+		"diff-del":    "160",
+		"diff-add-bg": "22",
+		"diff-del-bg": "52",
+		"diff-hunk":   "37",
+		"match":       "214",
+	},
+	"light": {
+		"title":       "162",
+		"subtitle":    "243",
+		"selected":    "125",
+		"dim":         "245",
+		"error":       "160",
+		"border":      "61",
+		"help":        "242",
+		"diff-add":    "28",
+		"diff-del":    "124",
+		"diff-add-bg": "194",
+		"diff-del-bg": "224",
+		"diff-hunk":   "30",
+		"match":       "166",
+	},
+	// high-contrast keeps text in the terminal's foreground colour, which is
+	// readable on any background, and uses the basic ANSI colours for accents.
+	"high-contrast": {
+		"title":       "",
+		"subtitle":    "",
+		"selected":    "12",
+		"dim":         "",
+		"error":       "9",
+		"border":      "",
+		"help":        "",
+		"diff-add":    "10",
+		"diff-del":    "9",
+		"diff-add-bg": "",
+		"diff-del-bg": "",
+		"diff-hunk":   "14",
+		"match":       "11",
+	},
+	"custom": {},
+}
+
+// codeStyles names the chroma style that highlights code in the diff viewer
+// for each theme. The other themes colour diff lines by kind only.
+var codeStyles = map[string]string{
+	"dark":  "monokai",
+	"light": "github",
+}
 
 var (
+	titleStyle            lipgloss.Style
+	subtitleStyle         lipgloss.Style
+	selectedStyle         lipgloss.Style
+	dimStyle              lipgloss.Style
+	errorStyle            lipgloss.Style
+	previewStyle          lipgloss.Style
+	helpStyle             lipgloss.Style
+	diffAddStyle          lipgloss.Style
+	diffDelStyle          lipgloss.Style
+	diffAddBgStyle        lipgloss.Style
+	diffDelBgStyle        lipgloss.Style
+	diffHunkStyle         lipgloss.Style
+	diffMetaStyle         lipgloss.Style
+	diffMatchStyle        lipgloss.Style
+	diffCurrentMatchStyle lipgloss.Style
+
+	// codeStyle highlights code in the diff viewer; nil turns it off.
+	codeStyle *chroma.Style
+)
+
+func init() {
+	applyTheme("dark", nil)
+}
+
+// applyTheme sets the styles from the named theme, with colors (cx.ui.color.*)
+// on top. An unknown name falls back to the dark theme.
+func applyTheme(name string, colors map[string]string) {
+	palette, ok := themes[name]
+	if !ok {
+		name, palette = "dark", themes["dark"]
+	}
+	palette = maps.Clone(palette)
+	maps.Copy(palette, colors)
+	color := func(style string) lipgloss.TerminalColor {
+		if c := palette[style]; c != "" {
+			return lipgloss.Color(c)
+		}
+		return lipgloss.NoColor{}
+	}
+
 	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("205"))
+		Bold(true).
+		Foreground(color("title"))
 
 	subtitleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
+		Foreground(color("subtitle"))
 
 	selectedStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("212"))
+		Bold(true).
+		Foreground(color("selected"))
 
 	dimStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("238"))
+		Foreground(color("dim"))
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
-			Bold(true)
+		Foreground(color("error")).
+		Bold(true)
 
 	previewStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("63")).
-			Padding(0, 1)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(color("border")).
+		Padding(0, 1)
 
 	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
-)
+		Foreground(color("help"))
+
+	diffAddStyle = lipgloss.NewStyle().
+		Foreground(color("diff-add"))
+
+	diffDelStyle = lipgloss.NewStyle().
+		Foreground(color("diff-del"))
+
+	diffAddBgStyle = lipgloss.NewStyle().
+		Background(color("diff-add-bg"))
+
+	diffDelBgStyle = lipgloss.NewStyle().
+		Background(color("diff-del-bg"))
+
+	diffHunkStyle = lipgloss.NewStyle().
+		Foreground(color("diff-hunk"))
+
+	diffMetaStyle = lipgloss.NewStyle().
+		Bold(true)
+
+	diffMatchStyle = lipgloss.NewStyle().
+		Reverse(true)
+
+	diffCurrentMatchStyle = lipgloss.NewStyle().
+		Reverse(true).
+		Foreground(color("match"))
+
+	codeStyle = nil
+	if s, ok := codeStyles[name]; ok {
+		codeStyle = styles.Get(s)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hayatosc/git-cx/internal/commit"
+	"github.com/hayatosc/git-cx/internal/git"
+)
+
+// errLintFailed is returned when at least one message has lint errors.
+var errLintFailed = errors.New("commit message lint failed")
+
+type lintTarget struct {
+	Source  string // file path or commit hash
+	Message string
+}
+
+type lintIssueJSON struct {
+	Rule    string `json:"rule"`
+	Level   string `json:"level"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+type lintResultJSON struct {
+	Source string          `json:"source"`
+	Header string          `json:"header"`
+	Valid  bool            `json:"valid"`
+	Issues []lintIssueJSON `json:"issues"`
+}
+
+func newLintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint [--file F | <rev-range>]",
+		Short: "Check commit messages against Conventional Commits rules",
+		Long: `Lint a commit message file, a single commit or a range of commits.
+
+Without arguments HEAD is checked. Use --file - to read a message from stdin.
+Merge, revert and fixup!/squash! messages generated by git are skipped.
+
+Rules are configured with cx.lint.* (types, subjectCase, maxHeaderLength,
+bodyMaxLineLength, disable, warn); scopes come from cx.commit.scopes and
+types default to the commit types configured with cx.types.*.
+Comment lines are stripped as git does, following core.commentChar and
+commit.cleanup.
+
+Exit status is 0 when no errors were found (warnings allowed), 1 when any
+message has errors, and 2 when the input could not be read.`,
+		Example: `  git cx lint
+  git cx lint main..HEAD
+  git cx lint --file .git/COMMIT_EDITMSG --format json`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE:         runLint,
+	}
+	cmd.Flags().String("file", "", "lint the message in this file (- for stdin)")
+	cmd.Flags().String("format", "text", "output format: text or json")
+	return cmd
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+	gitRunner := git.NewRunner()
+
+	format, _ := cmd.Flags().GetString("format")
+	if format != "text" && format != "json" {
+		return &exitCodeError{code: 2, err: fmt.Errorf("unknown format %q (valid formats: text, json)", format)}
+	}
+	file, _ := cmd.Flags().GetString("file")
+	if file != "" && len(args) > 0 {
+		return &exitCodeError{code: 2, err: errors.New("--file and a revision range are mutually exclusive")}
+	}
+
+	cfg, err := loadConfig(cmd, gitRunner)
+	if err != nil {
+		return &exitCodeError{code: 2, err: err}
+	}
+	rules, err := cfg.LintRules()
+	if err != nil {
+		return &exitCodeError{code: 2, err: err}
+	}
+	rules.Cleanup = commit.Cleanup{Mode: gitRunner.ConfigGet(ctx, "commit.cleanup"), CommentChar: gitRunner.CommentChar(ctx)}
+	targets, err := lintTargets(ctx, gitRunner, file, args, cmd.InOrStdin())
+	if err != nil {
+		return &exitCodeError{code: 2, err: err}
+	}
+
+	failed := false
+	results := make([]lintResultJSON, 0, len(targets))
+	for _, target := range targets {
+		issues := commit.Lint(target.Message, rules)
+		if commit.HasErrors(issues) {
+			failed = true
+		}
+		results = append(results, lintResult(target, rules.Cleanup, issues))
+	}
+
+	out := cmd.OutOrStdout()
+	if format == "json" {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return &exitCodeError{code: 2, err: err}
+		}
+	} else {
+		writeLintText(out, results)
+	}
+	if failed {
+		cmd.SilenceErrors = true
+		return &exitCodeError{code: 1, err: errLintFailed}
+	}
+	return nil
+}
+
+func lintTargets(ctx context.Context, runner git.Runner, file string, args []string, stdin io.Reader) ([]lintTarget, error) {
+	switch {
+	case file == "-":
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("read stdin: %w", err)
+		}
+		return []lintTarget{{Source: "stdin", Message: string(data)}}, nil
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read message file: %w", err)
+		}
+		return []lintTarget{{Source: file, Message: string(data)}}, nil
+	}
+
+	rev := "HEAD"
+	if len(args) > 0 {
+		rev = args[0]
+	}
+	if !strings.Contains(rev, "..") {
+		entry, err := runner.CommitMessage(ctx, rev)
+		if err != nil {
+			return nil, err
+		}
+		return []lintTarget{{Source: entry.Hash, Message: entry.Message}}, nil
+	}
+	entries, err := runner.Log(ctx, rev)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]lintTarget, len(entries))
+	for i, e := range entries {
+		targets[i] = lintTarget{Source: e.Hash, Message: e.Message}
+	}
+	return targets, nil
+}
+
+func lintResult(target lintTarget, cleanup commit.Cleanup, issues []commit.LintIssue) lintResultJSON {
+	header, _, _ := strings.Cut(cleanup.Clean(target.Message), "\n")
+	res := lintResultJSON{
+		Source: target.Source,
+		Header: header,
+		Valid:  !commit.HasErrors(issues),
+		Issues: make([]lintIssueJSON, 0, len(issues)),
+	}
+	for _, i := range issues {
+		res.Issues = append(res.Issues, lintIssueJSON{
+			Rule:    i.Rule,
+			Level:   string(i.Level),
+			Line:    i.Line,
+			Message: i.Message,
+		})
+	}
+	return res
+}
+
+func writeLintText(w io.Writer, results []lintResultJSON) {
+	errorsFound, warnings := 0, 0
+	for _, res := range results {
+		if len(res.Issues) == 0 {
+			continue
+		}
+		source := res.Source
+		if len(source) == 40 {
+			source = source[:7]
+		}
+		fmt.Fprintf(w, "%s: %s\n", source, res.Header)
+		for _, i := range res.Issues {
+			fmt.Fprintf(w, "  %d: %s [%s] %s\n", i.Line, i.Level, i.Rule, i.Message)
+			if i.Level == string(commit.LevelError) {
+				errorsFound++
+			} else {
+				warnings++
+			}
+		}
+	}
+	fmt.Fprintf(w, "%d message(s) checked, %d error(s), %d warning(s)\n", len(results), errorsFound, warnings)
+}
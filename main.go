@@ -10,16 +10,28 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/term"
 
 	"github.com/hayatosc/git-cx/internal/ai"
 	"github.com/hayatosc/git-cx/internal/app"
+	"github.com/hayatosc/git-cx/internal/commit"
 	"github.com/hayatosc/git-cx/internal/config"
 	"github.com/hayatosc/git-cx/internal/git"
+	"github.com/hayatosc/git-cx/internal/plain"
 	"github.com/hayatosc/git-cx/internal/tui"
 )
 
 var version = "dev"
 
+// exitCodeError makes main exit with a specific status code.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+
 // inGitHook reports whether git-cx is being invoked from a Git hook by
 // checking Git-provided environment variables.
 func inGitHook() bool {
@@ -42,13 +54,32 @@ func main() {
 	root.PersistentFlags().String("command", "", "command template for custom provider")
 	root.PersistentFlags().String("api-base-url", "", "base URL for api provider")
 	root.PersistentFlags().Bool("use-emoji", false, "prefix commit type with emoji")
+	root.PersistentFlags().Bool("gitmoji", false, "choose the emoji from the full gitmoji catalogue")
 	root.PersistentFlags().Int("max-subject-length", 0, "max length of commit subject line")
 	root.PersistentFlags().Bool("dry-run", false, "preview commit message without actually committing")
+	root.Flags().BoolP("signoff", "s", false, "add a Signed-off-by trailer (git commit --signoff)")
+	root.Flags().BoolP("gpg-sign", "S", false, "GPG-sign the commit (git commit -S)")
+	root.Flags().BoolP("no-verify", "n", false, "bypass the pre-commit and commit-msg hooks")
+	root.Flags().Bool("allow-empty", false, "allow a commit without staged changes")
+	root.Flags().String("author", "", "override the commit author (git commit --author)")
+	root.Flags().String("date", "", "override the author date (git commit --date)")
+	root.Flags().String("ui", "auto", "interface: tui, plain (line-oriented prompts) or auto (plain when stdout is not a terminal or TERM=dumb)")
+	root.Flags().String("message-file", "", "write the chosen message to this file instead of committing (used by the prepare-commit-msg hook)")
 
 	root.AddCommand(newConfigCmd())
+	root.AddCommand(newHookCmd())
+	root.AddCommand(newLintCmd())
+	root.AddCommand(newChangelogCmd())
+	root.AddCommand(newNextVersionCmd())
+	root.AddCommand(newPRCmd())
+	root.AddCommand(newBranchCmd())
 	root.AddCommand(newVersionCmd())
 
 	if err := root.Execute(); err != nil {
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		os.Exit(1)
 	}
 }
@@ -108,6 +139,7 @@ func loadConfig(cmd *cobra.Command, runner git.Runner) (*config.Config, error) {
 		func() error { return applyStringFlag(flags, "command", &cfg.Command) },
 		func() error { return applyStringFlag(flags, "api-base-url", &cfg.API.BaseURL) },
 		func() error { return applyBoolFlag(flags, "use-emoji", &cfg.Commit.UseEmoji) },
+		func() error { return applyBoolFlag(flags, "gitmoji", &cfg.Commit.Gitmoji) },
 		func() error { return applyIntFlag(flags, "max-subject-length", &cfg.Commit.MaxSubjectLength) },
 	} {
 		if err := fn(); err != nil {
@@ -132,29 +164,70 @@ func runCommit(cmd *cobra.Command, _ []string) error {
 	}
 
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	messageFile, _ := cmd.Flags().GetString("message-file")
+	plainUI, err := usePlainUI(cmd.Flags())
+	if err != nil {
+		return err
+	}
 
 	commitService := app.NewCommitService(cfg, provider, gitRunner)
-	diff, stat, err := commitService.StagedChanges(ctx)
+	if messageFile != "" {
+		commitService.SetMessageFile(messageFile)
+	}
+	commitOpts, err := commitOptionsFromFlags(cmd.Flags(), commitService.CommitOptions())
 	if err != nil {
-		if errors.Is(err, git.ErrNoStagedChanges) {
-			if !dryRun {
-				fmt.Fprintln(os.Stderr, "Error: no staged changes. Run 'git add' first.")
-				os.Exit(1)
-			}
-			diff, _ = gitRunner.UnstagedDiff(ctx)
-			stat, _ = gitRunner.UnstagedStat(ctx)
-			if strings.TrimSpace(diff) == "" {
-				diff, _ = gitRunner.LastCommitDiff(ctx)
-				stat, _ = gitRunner.LastCommitStat(ctx)
-			}
-		} else {
-			return fmt.Errorf("failed to get staged diff: %w", err)
+		return err
+	}
+	commitService.SetCommitOptions(commitOpts)
+	op := commitService.InProgress(ctx)
+	diff, stat, err := commitService.StagedChanges(ctx)
+	switch {
+	case err == nil:
+	case errors.Is(err, git.ErrNoStagedChanges) && op.Kind == git.OperationMerge:
+		// A merge that brings in no changes still needs its merge commit.
+	case errors.Is(err, git.ErrNoStagedChanges) && commitOpts.AllowEmpty:
+		// --allow-empty: there is no diff to describe, the message is typed in.
+	case errors.Is(err, git.ErrNoStagedChanges):
+		if messageFile != "" {
+			// Nothing to describe (e.g. --allow-empty); leave git's message untouched.
+			return nil
+		}
+		if !dryRun {
+			fmt.Fprintln(os.Stderr, "Error: no staged changes. Run 'git add' first.")
+			os.Exit(1)
+		}
+		diff, _ = gitRunner.UnstagedDiff(ctx)
+		stat, _ = gitRunner.UnstagedStat(ctx)
+		if strings.TrimSpace(diff) == "" {
+			diff, _ = gitRunner.LastCommitDiff(ctx)
+			stat, _ = gitRunner.LastCommitStat(ctx)
 		}
+	default:
+		return fmt.Errorf("failed to get staged diff: %w", err)
 	}
 
-	m := tui.New(commitService, diff, stat, dryRun)
+	if messageFile != "" && !term.IsTerminal(int(os.Stdin.Fd())) {
+		return writeFirstCandidate(ctx, commitService, diff, stat)
+	}
+
+	if op.Kind != git.OperationNone {
+		fmt.Fprintf(os.Stderr, "git-cx: %s in progress, generating the message that concludes it.\n", op.Kind)
+	}
 
-	hookMode := inGitHook()
+	if plainUI {
+		err := plain.New(commitService, diff, stat, dryRun, os.Stdin, os.Stdout).WithOperation(op).Run(ctx)
+		if errors.Is(err, plain.ErrAborted) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+		return err
+	}
+
+	m := tui.New(commitService, diff, stat, dryRun).WithOperation(op)
+	if !dryRun && op.Kind == git.OperationNone {
+		m = m.WithDrafts()
+	}
+	hookMode := inGitHook() || messageFile != ""
 	opts := []tea.ProgramOption{}
 	if !hookMode {
 		opts = append(opts, tea.WithAltScreen())
@@ -175,6 +248,62 @@ func runCommit(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// usePlainUI reports whether --ui asks for the line-oriented prompts. With
+// auto they are used where the TUI cannot draw: when stdout is not a terminal
+// or TERM=dumb (e.g. Emacs shell-mode).
+func usePlainUI(flags *pflag.FlagSet) (bool, error) {
+	ui, err := flags.GetString("ui")
+	if err != nil {
+		return false, err
+	}
+	switch ui {
+	case "tui":
+		return false, nil
+	case "plain":
+		return true, nil
+	case "auto":
+		return !term.IsTerminal(int(os.Stdout.Fd())) || os.Getenv("TERM") == "dumb", nil
+	}
+	return false, fmt.Errorf("invalid --ui %q: must be auto, tui or plain", ui)
+}
+
+// commitOptionsFromFlags applies the git commit pass-through flags on top of
+// opts, which holds the configured defaults.
+func commitOptionsFromFlags(flags *pflag.FlagSet, opts git.CommitOptions) (git.CommitOptions, error) {
+	for _, fn := range []func() error{
+		func() error { return applyBoolFlag(flags, "signoff", &opts.Signoff) },
+		func() error { return applyBoolFlag(flags, "gpg-sign", &opts.GPGSign) },
+		func() error { return applyBoolFlag(flags, "no-verify", &opts.NoVerify) },
+		func() error { return applyBoolFlag(flags, "allow-empty", &opts.AllowEmpty) },
+		func() error { return applyStringFlag(flags, "author", &opts.Author) },
+		func() error { return applyStringFlag(flags, "date", &opts.Date) },
+	} {
+		if err := fn(); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// writeFirstCandidate fills the hook message file without a TUI, for commits
+// started from IDEs and other tools that have no terminal attached.
+func writeFirstCandidate(ctx context.Context, service *app.CommitService, diff, stat string) error {
+	candidates, err := service.GenerateCandidates(ctx, diff, stat, "", "")
+	if err != nil || len(candidates) == 0 {
+		if err == nil {
+			err = errors.New("no candidates returned")
+		}
+		fmt.Fprintf(os.Stderr, "git-cx: could not generate a commit message: %v\n", err)
+		return nil
+	}
+	message, err := service.FinalMessage(ctx, &commit.ConventionalCommit{Subject: candidates[0].Header}, service.DefaultTrailers(ctx))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "git-cx: could not apply trailers: %v\n", err)
+	}
+	_, err = service.Commit(ctx, message)
+	return err
+}
+
 func newVersionCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
@@ -221,11 +350,46 @@ Example:
 				keyStatus = "<set>"
 			}
 			fmt.Printf("apiKey (OPENAI_API_KEY):   %s\n", keyStatus)
+			if len(cfg.CoAuthors) > 0 {
+				fmt.Printf("coauthors:                 %v\n", cfg.CoAuthors)
+			}
+			fmt.Printf("types:                     %s\n", cfg.Types)
 			fmt.Printf("commit.useEmoji:           %v\n", cfg.Commit.UseEmoji)
+			fmt.Printf("commit.gitmoji:            %v\n", cfg.Commit.Gitmoji)
+			fmt.Printf("commit.emojiPlacement:     %s\n", cfg.Commit.EmojiPlacement)
+			fmt.Printf("commit.emojiFormat:        %s\n", cfg.Commit.EmojiFormat)
 			fmt.Printf("commit.maxSubjectLength:   %d\n", cfg.Commit.MaxSubjectLength)
+			fmt.Printf("commit.bodyWrap:           %d\n", cfg.Commit.BodyWrap)
+			fmt.Printf("commit.normalizeBullets:   %v\n", cfg.Commit.NormalizeBullets)
+			fmt.Printf("commit.signoff:            %v\n", cfg.Commit.Signoff)
+			fmt.Printf("commit.gpgSign:            %v\n", cfg.Commit.GPGSign)
 			if len(cfg.Commit.Scopes) > 0 {
 				fmt.Printf("commit.scopes:             %v\n", cfg.Commit.Scopes)
 			}
+			if len(cfg.Commit.Trailers) > 0 {
+				fmt.Printf("commit.trailers:           %v\n", cfg.Commit.Trailers)
+			}
+			if len(cfg.Lint.Types) > 0 {
+				fmt.Printf("lint.types:                %v\n", cfg.Lint.Types)
+			}
+			fmt.Printf("lint.subjectCase:          %s\n", cfg.Lint.SubjectCase)
+			fmt.Printf("lint.maxHeaderLength:      %d\n", cfg.Lint.MaxHeaderLength)
+			fmt.Printf("lint.bodyMaxLineLength:    %d\n", cfg.Lint.BodyMaxLineLength)
+			if len(cfg.Lint.Disable) > 0 {
+				fmt.Printf("lint.disable:              %v\n", cfg.Lint.Disable)
+			}
+			if len(cfg.Lint.Warn) > 0 {
+				fmt.Printf("lint.warn:                 %v\n", cfg.Lint.Warn)
+			}
+			fmt.Printf("branch.pattern:            %s\n", cfg.Branch.Pattern)
+			fmt.Printf("ui.theme:                  %s\n", cfg.UI.Theme)
+			if len(cfg.UI.Colors) > 0 {
+				fmt.Printf("ui.color:                  %v\n", cfg.UI.Colors)
+			}
+			fmt.Printf("ui.keymap:                 %s\n", cfg.UI.Keymap)
+			if len(cfg.UI.Keys) > 0 {
+				fmt.Printf("ui.key:                    %v\n", cfg.UI.Keys)
+			}
 			return nil
 		},
 	}
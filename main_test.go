@@ -1,6 +1,12 @@
 package main
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"github.com/hayatosc/git-cx/internal/ai"
+)
 
 func TestInGitHook(t *testing.T) {
 	t.Setenv("GIT_DIR", "/tmp/repo/.git")
@@ -28,3 +34,41 @@ func TestInGitHookPartialEnv(t *testing.T) {
 		t.Fatalf("expected hook detection to require both env vars")
 	}
 }
+
+func TestUsePlainUI(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	for ui, want := range map[string]bool{"tui": false, "plain": true, "auto": true} {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		flags.String("ui", "auto", "")
+		if err := flags.Set("ui", ui); err != nil {
+			t.Fatal(err)
+		}
+		got, err := usePlainUI(flags)
+		if err != nil || got != want {
+			t.Errorf("--ui=%s: got %v, %v; want %v", ui, got, err, want)
+		}
+	}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("ui", "fancy", "")
+	if _, err := usePlainUI(flags); err == nil {
+		t.Errorf("expected an error for an unknown --ui")
+	}
+}
+
+func TestFormatBranchName(t *testing.T) {
+	name := ai.BranchName{Type: "feat", Slug: "Billing-Retry-Webhooks"}
+	tests := []struct {
+		pattern, ticket, want string
+	}{
+		{"{type}/{slug}", "", "feat/billing-retry-webhooks"},
+		{"{type}/{ticket}-{slug}", "ABC-123", "feat/ABC-123-billing-retry-webhooks"},
+		{"{type}/{ticket}-{slug}", "", "feat/billing-retry-webhooks"},
+		{"{ticket}/{type}/{slug}", "", "feat/billing-retry-webhooks"},
+	}
+	for _, tt := range tests {
+		if got := formatBranchName(tt.pattern, name, tt.ticket); got != tt.want {
+			t.Errorf("formatBranchName(%q, %q) = %q, want %q", tt.pattern, tt.ticket, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hayatosc/git-cx/internal/changelog"
+	"github.com/hayatosc/git-cx/internal/git"
+	"github.com/hayatosc/git-cx/internal/semver"
+)
+
+func newNextVersionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "next-version",
+		Short: "Print the next semantic version based on Conventional Commits",
+		Long: `Compute the next version from the commits since the latest semver tag.
+
+Breaking changes ('!' or a BREAKING CHANGE footer) bump the major version,
+feat bumps the minor version, and fix or perf bump the patch version. Other
+types do not trigger a release unless cx.types.<type>.bump says otherwise.
+Without tags the base version is 0.0.0.
+
+With --pre the result is a pre-release such as 1.3.0-rc.1; existing
+pre-releases for the same version and channel are continued (rc.2, rc.3, ...).
+With --tag an annotated tag is created at HEAD with the changelog as its message.`,
+		Example: `  git cx next-version
+  git cx next-version --pre rc
+  git cx next-version --tag`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runNextVersion,
+	}
+	cmd.Flags().String("pre", "", "pre-release channel (e.g. alpha, beta, rc)")
+	cmd.Flags().Bool("no-prefix", false, "print the version without a leading 'v'")
+	cmd.Flags().Bool("tag", false, "create an annotated tag with generated release notes")
+	return cmd
+}
+
+func runNextVersion(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+	gitRunner := git.NewRunner()
+	flags := cmd.Flags()
+	channel, _ := flags.GetString("pre")
+	noPrefix, _ := flags.GetBool("no-prefix")
+	createTag, _ := flags.GetBool("tag")
+
+	cfg, err := loadConfig(cmd, gitRunner)
+	if err != nil {
+		return err
+	}
+	tags, err := gitRunner.MergedTags(ctx)
+	if err != nil {
+		return err
+	}
+	stable, pres, found := semver.Latest(tags)
+	revRange := "HEAD"
+	if found {
+		revRange = tagFor(tags, stable) + "..HEAD"
+	} else {
+		stable = semver.Version{Prefix: "v"}
+	}
+	commits, err := gitRunner.Log(ctx, revRange)
+	if err != nil {
+		return err
+	}
+
+	bump := changelog.Bump(commits, cfg.Types)
+	if bump == semver.BumpNone {
+		fmt.Fprintf(os.Stderr, "git-cx: no releasable or breaking commits since %s\n", stable)
+		if createTag {
+			return fmt.Errorf("nothing to release")
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), formatVersion(stable, noPrefix))
+		return nil
+	}
+	next := semver.Next(stable, bump, channel, pres)
+	if channel != "" {
+		if _, err := semver.Parse(next.String()); err != nil {
+			return fmt.Errorf("invalid pre-release channel %q: %w", channel, err)
+		}
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), formatVersion(next, noPrefix))
+
+	if !createTag {
+		return nil
+	}
+	notes := changelog.Build(commits, changelog.Options{
+		Version: next.String(),
+		Date:    time.Now().Format("2006-01-02"),
+		Types:   cfg.Types,
+	}).Markdown()
+	if err := gitRunner.CreateAnnotatedTag(ctx, next.String(), notes); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "git-cx: created tag %s (%s bump)\n", next, bump)
+	return nil
+}
+
+// tagFor returns the tag name that parses to v.
+func tagFor(tags []string, v semver.Version) string {
+	for _, tag := range tags {
+		if parsed, err := semver.Parse(tag); err == nil && semver.Compare(parsed, v) == 0 && parsed.Prefix == v.Prefix {
+			return tag
+		}
+	}
+	return v.String()
+}
+
+func formatVersion(v semver.Version, noPrefix bool) string {
+	if noPrefix {
+		v.Prefix = ""
+	}
+	return v.String()
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/hayatosc/git-cx/internal/ai"
+	"github.com/hayatosc/git-cx/internal/git"
+	"github.com/hayatosc/git-cx/internal/tui"
+)
+
+// errAborted is returned when the user leaves a picker without choosing.
+var errAborted = errors.New("aborted")
+
+// prTemplatePaths are the locations GitHub looks for a pull request template.
+var prTemplatePaths = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+func newPRCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pr",
+		Short: "Generate a pull request title and description",
+		Long: `Generate a pull request title and Markdown description for the current branch.
+
+The branch is compared with --base (default: the upstream of the current
+branch when it tracks another branch, else origin/HEAD, or main). The diff
+and the commit messages on the branch are sent to the AI provider, which
+returns title candidates and a description with summary, change list and
+testing notes. Pick a title in the TUI; without a terminal the first is used.
+
+A pull request template (.github/pull_request_template.md and the other
+locations GitHub supports) is filled in automatically; use --template to
+point at another file or --no-template to ignore it.
+
+The result is printed as "<title>\n\n<body>" (or JSON with --format json).`,
+		Example: `  git cx pr
+  git cx pr --base develop -o pr.md
+  git cx pr --format json | jq -r .body`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runPR,
+	}
+	cmd.Flags().String("base", "", "base branch to compare against (default: the branch's upstream, origin/HEAD or main)")
+	cmd.Flags().String("template", "", "pull request template to fill in (default: auto-detect)")
+	cmd.Flags().Bool("no-template", false, "ignore the repository's pull request template")
+	cmd.Flags().String("format", "text", "output format: text or json")
+	cmd.Flags().StringP("output", "o", "", "write to this file instead of stdout")
+	return cmd
+}
+
+func runPR(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+	gitRunner := git.NewRunner()
+	flags := cmd.Flags()
+
+	format, _ := flags.GetString("format")
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unknown format %q (valid formats: text, json)", format)
+	}
+	cfg, err := loadConfig(cmd, gitRunner)
+	if err != nil {
+		return err
+	}
+	provider, err := ai.NewProvider(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AI provider: %w", err)
+	}
+
+	base, _ := flags.GetString("base")
+	if base == "" {
+		base = gitRunner.BaseBranch(ctx)
+	}
+	diff, err := gitRunner.BranchDiff(ctx, base)
+	if err != nil {
+		return err
+	}
+	if diff == "" {
+		return fmt.Errorf("no changes between %s and HEAD", base)
+	}
+	stat, err := gitRunner.BranchStat(ctx, base)
+	if err != nil {
+		return err
+	}
+	entries, err := gitRunner.Log(ctx, base+"..HEAD")
+	if err != nil {
+		return err
+	}
+	commits := make([]string, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		commits = append(commits, entries[i].Message)
+	}
+	template, err := prTemplate(ctx, cmd, gitRunner)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "git-cx: generating pull request description...")
+	draft, err := ai.GeneratePR(ctx, provider, ai.PRRequest{
+		Base:       base,
+		Diff:       diff,
+		Stat:       stat,
+		Commits:    commits,
+		Template:   template,
+		Candidates: cfg.Candidates,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate pull request: %w", err)
+	}
+	title, err := pickCandidate("Select pull request title", draft.Titles)
+	if err != nil {
+		return err
+	}
+
+	var out string
+	if format == "json" {
+		data, err := json.MarshalIndent(struct {
+			Title string `json:"title"`
+			Body  string `json:"body"`
+		}{title, draft.Body}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode pull request: %w", err)
+		}
+		out = string(data) + "\n"
+	} else {
+		out = title + "\n\n" + draft.Body + "\n"
+	}
+
+	path, _ := flags.GetString("output")
+	if path == "" {
+		fmt.Fprint(cmd.OutOrStdout(), out)
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(out), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// prTemplate returns the pull request template to fill in, if any.
+func prTemplate(ctx context.Context, cmd *cobra.Command, runner git.Runner) (string, error) {
+	if skip, _ := cmd.Flags().GetBool("no-template"); skip {
+		return "", nil
+	}
+	if path, _ := cmd.Flags().GetString("template"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read template: %w", err)
+		}
+		return string(data), nil
+	}
+	root, err := runner.TopLevel(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, rel := range prTemplatePaths {
+		if data, err := os.ReadFile(filepath.Join(root, rel)); err == nil {
+			return string(data), nil
+		}
+	}
+	return "", nil
+}
+
+// pickCandidate lets the user choose one of options in a TUI drawn on stderr,
+// so stdout stays clean for piping. Without a terminal the first option is used.
+func pickCandidate(title string, options []string) (string, error) {
+	if len(options) == 1 || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return options[0], nil
+	}
+	result, err := tea.NewProgram(tui.NewPicker(title, options), tea.WithOutput(os.Stderr)).Run()
+	if err != nil {
+		return "", fmt.Errorf("TUI error: %w", err)
+	}
+	choice, ok := result.(tui.Picker).Choice()
+	if !ok || strings.TrimSpace(choice) == "" {
+		return "", errAborted
+	}
+	return choice, nil
+}